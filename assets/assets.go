@@ -11,7 +11,3 @@ type ContentId struct {
 func Preload(contentId ContentId) {
 	preload(ffi.Serialize(contentId).ToPacked())
 }
-
-//go:wasm-module assets
-//export preload
-func preload(contentId uint64)
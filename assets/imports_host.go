@@ -0,0 +1,12 @@
+//go:build !wasm
+
+package assets
+
+import (
+	"github.com/oriolus-software/script-go/internal/ffi"
+	"github.com/oriolus-software/script-go/internal/host"
+)
+
+func preload(contentId uint64) {
+	host.Current.Preload(ffi.Deserialize[host.ContentId](contentId))
+}
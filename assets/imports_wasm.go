@@ -0,0 +1,7 @@
+//go:build wasm
+
+package assets
+
+//go:wasm-module assets
+//export preload
+func preload(contentId uint64)
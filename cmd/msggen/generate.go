@@ -0,0 +1,280 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"strings"
+
+	"github.com/oriolus-software/script-go/schema"
+)
+
+var goTypes = map[string]string{
+	"bool": "bool", "string": "string", "bytes": "[]byte",
+	"i8": "int8", "i16": "int16", "i32": "int32", "i64": "int64",
+	"u8": "uint8", "u16": "uint16", "u32": "uint32", "u64": "uint64",
+	"f32": "float32", "f64": "float64",
+}
+
+type generator struct {
+	b strings.Builder
+}
+
+func (g *generator) p(format string, args ...any) {
+	fmt.Fprintf(&g.b, format, args...)
+	g.b.WriteByte('\n')
+}
+
+// generate returns the formatted Go source for all types of s.
+func generate(s *schema.Schema, pkg, source string) ([]byte, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	g := &generator{}
+
+	g.p("// Code generated by msggen from %s. DO NOT EDIT.", source)
+	g.p("")
+	g.p("package %s", pkg)
+	g.p("")
+	g.p("import (")
+	if len(s.Messages) > 0 {
+		g.p("%q", "github.com/oriolus-software/script-go/message")
+	}
+	g.p("%q", "github.com/oriolus-software/script-go/msgpack")
+	g.p(")")
+
+	for _, m := range s.Messages {
+		if err := g.structType(m.Name, m.Fields); err != nil {
+			return nil, err
+		}
+
+		g.p("")
+		g.p("func (%s) Meta() message.Meta {", m.Name)
+		g.p("return message.Meta{Namespace: %q, Identifier: %q, Bus: %q}", m.Namespace, m.Identifier, m.Bus)
+		g.p("}")
+		g.p("")
+		g.p("// On%s registers a handler for %s messages.", m.Name, m.Name)
+		g.p("func On%s(handler func(message.Incoming[%s])) *message.Subscription {", m.Name, m.Name)
+		g.p("return message.RegisterHandler(handler)")
+		g.p("}")
+	}
+
+	for _, st := range s.Structs {
+		if err := g.structType(st.Name, st.Fields); err != nil {
+			return nil, err
+		}
+	}
+
+	src, err := format.Source([]byte(g.b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+func (g *generator) structType(name string, fields []schema.Field) error {
+	types := make([]schema.Type, len(fields))
+	for i, f := range fields {
+		t, err := schema.ParseType(f.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", name, f.Key, err)
+		}
+		types[i] = t
+	}
+
+	g.p("")
+	g.p("type %s struct {", name)
+	for i, f := range fields {
+		typ, tag := goType(types[i]), fmt.Sprintf("msgpack:%q", f.Key)
+		switch {
+		case f.Optional && (types[i].Kind == "list" || types[i].Kind == "bytes"):
+			tag += ` schema:"optional"`
+		case f.Optional:
+			typ = "*" + typ
+		}
+		g.p("%s %s `%s`", f.GoName(), typ, tag)
+	}
+	g.p("}")
+
+	g.p("")
+	g.p("func (m %s) MarshalMsgpack(w *msgpack.Writer) error {", name)
+	g.p("if err := w.WriteMapHeader(%d); err != nil {", len(fields))
+	g.p("return err")
+	g.p("}")
+	for i, f := range fields {
+		g.p("")
+		g.p("if err := w.WriteString(%q); err != nil {", f.Key)
+		g.p("return err")
+		g.p("}")
+
+		value := "m." + f.GoName()
+		switch {
+		case f.Optional && (types[i].Kind == "list" || types[i].Kind == "bytes"):
+			g.p("if %s == nil {", value)
+			g.p("if err := w.WriteNil(); err != nil {")
+			g.p("return err")
+			g.p("}")
+			g.p("} else {")
+			g.encode(types[i], value, 0)
+			g.p("}")
+		case f.Optional:
+			g.p("if %s == nil {", value)
+			g.p("if err := w.WriteNil(); err != nil {")
+			g.p("return err")
+			g.p("}")
+			g.p("} else {")
+			g.encode(types[i], "*"+value, 0)
+			g.p("}")
+		default:
+			g.encode(types[i], value, 0)
+		}
+	}
+	g.p("")
+	g.p("return nil")
+	g.p("}")
+
+	g.p("")
+	g.p("func (m *%s) UnmarshalMsgpack(r *msgpack.Reader) error {", name)
+	g.p("h, err := r.ReadMapHeader()")
+	g.p("if err != nil {")
+	g.p("return err")
+	g.p("}")
+	g.p("")
+	g.p("for i := 0; i < h; i++ {")
+	g.p("key, err := r.ReadString()")
+	g.p("if err != nil {")
+	g.p("return err")
+	g.p("}")
+	g.p("")
+	g.p("switch key {")
+	for i, f := range fields {
+		g.p("case %q:", f.Key)
+		target := "m." + f.GoName()
+		if f.Optional {
+			g.p("if r.IsNil() {")
+			g.p("if err := r.ReadNil(); err != nil {")
+			g.p("return err")
+			g.p("}")
+			g.p("%s = nil", target)
+			g.p("continue")
+			g.p("}")
+			if types[i].Kind != "list" && types[i].Kind != "bytes" {
+				g.p("%s = new(%s)", target, goType(types[i]))
+				target = "*" + target
+			}
+		}
+		g.decode(types[i], target, 0)
+	}
+	g.p("default:")
+	g.p("if err := r.Skip(); err != nil {")
+	g.p("return err")
+	g.p("}")
+	g.p("}")
+	g.p("}")
+	g.p("")
+	g.p("return nil")
+	g.p("}")
+
+	return nil
+}
+
+func goType(t schema.Type) string {
+	switch t.Kind {
+	case "list":
+		return "[]" + goType(*t.Elem)
+	case "struct":
+		return t.Name
+	}
+
+	return goTypes[t.Kind]
+}
+
+// encode writes the statements encoding the Go expression value of type t.
+func (g *generator) encode(t schema.Type, value string, depth int) {
+	var call string
+
+	switch t.Kind {
+	case "bool":
+		call = fmt.Sprintf("w.WriteBool(%s)", value)
+	case "string":
+		call = fmt.Sprintf("w.WriteString(%s)", value)
+	case "bytes":
+		call = fmt.Sprintf("w.WriteBinary(%s)", value)
+	case "i8", "i16", "i32", "i64":
+		call = fmt.Sprintf("w.WriteInt(int64(%s))", value)
+	case "u8", "u16", "u32", "u64":
+		call = fmt.Sprintf("w.WriteUint(uint64(%s))", value)
+	case "f32":
+		call = fmt.Sprintf("w.WriteFloat32(%s)", value)
+	case "f64":
+		call = fmt.Sprintf("w.WriteFloat64(%s)", value)
+	case "struct":
+		// Optional structs are pointers, which have the methods as well.
+		call = fmt.Sprintf("%s.MarshalMsgpack(w)", strings.TrimPrefix(value, "*"))
+	case "list":
+		elem := fmt.Sprintf("v%d", depth)
+		g.p("if err := w.WriteArrayHeader(len(%s)); err != nil {", value)
+		g.p("return err")
+		g.p("}")
+		g.p("for _, %s := range %s {", elem, value)
+		g.encode(*t.Elem, elem, depth+1)
+		g.p("}")
+		return
+	}
+
+	g.p("if err := %s; err != nil {", call)
+	g.p("return err")
+	g.p("}")
+}
+
+// decode writes the statements decoding a value of type t into the
+// addressable Go expression target.
+func (g *generator) decode(t schema.Type, target string, depth int) {
+	var read, convert string
+
+	switch t.Kind {
+	case "bool":
+		read = "r.ReadBool()"
+	case "string":
+		read = "r.ReadString()"
+	case "bytes":
+		read = "r.ReadBinary()"
+	case "i8", "i16", "i32", "i64":
+		read, convert = "r.ReadInt()", goTypes[t.Kind]
+	case "u8", "u16", "u32", "u64":
+		read, convert = "r.ReadUint()", goTypes[t.Kind]
+	case "f32", "f64":
+		read, convert = "r.ReadFloat()", goTypes[t.Kind]
+	case "struct":
+		g.p("if err := %s.UnmarshalMsgpack(r); err != nil {", strings.TrimPrefix(target, "*"))
+		g.p("return err")
+		g.p("}")
+		return
+	case "list":
+		n, i := fmt.Sprintf("n%d", depth), fmt.Sprintf("i%d", depth)
+		g.p("{")
+		g.p("%s, err := r.ReadArrayHeader()", n)
+		g.p("if err != nil {")
+		g.p("return err")
+		g.p("}")
+		g.p("%s = make(%s, %s)", target, goType(t), n)
+		g.p("for %s := range %s {", i, target)
+		g.decode(*t.Elem, fmt.Sprintf("%s[%s]", target, i), depth+1)
+		g.p("}")
+		g.p("}")
+		return
+	}
+
+	g.p("{")
+	g.p("v, err := %s", read)
+	g.p("if err != nil {")
+	g.p("return err")
+	g.p("}")
+	if convert != "" {
+		g.p("%s = %s(v)", target, convert)
+	} else {
+		g.p("%s = v", target)
+	}
+	g.p("}")
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/oriolus-software/script-go/schema"
+)
+
+func TestGenerateMatchesExample(t *testing.T) {
+	s, err := schema.Load("internal/example/doors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := generate(s, "example", "doors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := os.ReadFile("internal/example/doors_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Fatal("internal/example/doors_gen.go is stale, run go generate ./cmd/msggen/...")
+	}
+}
+
+func TestGenerateRejectsUnknownType(t *testing.T) {
+	s := &schema.Schema{Structs: []schema.Struct{{
+		Name:   "Bad",
+		Fields: []schema.Field{{Key: "x", Type: "complex"}},
+	}}}
+
+	if _, err := generate(s, "bad", "bad.json"); err == nil {
+		t.Fatal("expected error for unknown type")
+	}
+}
+
+// TestGeneratedCodeCompiles runs go vet on the code generated for every
+// field type, both required and optional.
+func TestGeneratedCodeCompiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go vet")
+	}
+
+	types := []string{
+		"bool", "string", "bytes", "i8", "i16", "i32", "i64", "u8", "u16",
+		"u32", "u64", "f32", "f64", "Point", "list<i32>", "list<Point>",
+		"list<list<Point>>",
+	}
+
+	var fields []schema.Field
+	for i, typ := range types {
+		fields = append(fields,
+			schema.Field{Key: fmt.Sprintf("required_%d", i), Type: typ},
+			schema.Field{Key: fmt.Sprintf("optional_%d", i), Type: typ, Optional: true},
+		)
+	}
+
+	s := &schema.Schema{
+		Messages: []schema.Message{{Name: "All", Namespace: "test", Identifier: "all", Fields: fields}},
+		Structs: []schema.Struct{{Name: "Point", Fields: []schema.Field{
+			{Key: "x", Type: "f32"},
+			{Key: "label", Type: "string", Optional: true},
+		}}},
+	}
+
+	src, err := generate(s, "typecheck", "all.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The package has to be inside the module to import its internal
+	// packages. The underscore keeps it out of ./... patterns.
+	dir, err := os.MkdirTemp(".", "_typecheck")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	if err := os.WriteFile(filepath.Join(dir, "all_gen.go"), src, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := exec.Command("go", "vet", "./"+filepath.Base(dir)).CombinedOutput()
+	if err != nil {
+		t.Fatalf("generated code does not compile: %v\n%s", err, out)
+	}
+}
+
+func TestGenerateRejectsReservedNames(t *testing.T) {
+	s := &schema.Schema{Messages: []schema.Message{{
+		Name:       "Bad",
+		Namespace:  "bad",
+		Identifier: "bad",
+		Fields:     []schema.Field{{Key: "meta", Type: "string"}},
+	}}}
+
+	if _, err := generate(s, "bad", "bad.json"); err == nil {
+		t.Fatal("expected error for a field named Meta")
+	}
+}
@@ -0,0 +1,379 @@
+// Code generated by msggen from doors.json. DO NOT EDIT.
+
+package example
+
+import (
+	"github.com/oriolus-software/script-go/message"
+	"github.com/oriolus-software/script-go/msgpack"
+)
+
+type DoorState struct {
+	Open        bool    `msgpack:"open"`
+	Side        *string `msgpack:"side"`
+	DoorIndex   uint8   `msgpack:"door_index"`
+	Leaves      []Leaf  `msgpack:"leaves"`
+	BlockedLeaf *Leaf   `msgpack:"blocked_leaf"`
+}
+
+func (m DoorState) MarshalMsgpack(w *msgpack.Writer) error {
+	if err := w.WriteMapHeader(5); err != nil {
+		return err
+	}
+
+	if err := w.WriteString("open"); err != nil {
+		return err
+	}
+	if err := w.WriteBool(m.Open); err != nil {
+		return err
+	}
+
+	if err := w.WriteString("side"); err != nil {
+		return err
+	}
+	if m.Side == nil {
+		if err := w.WriteNil(); err != nil {
+			return err
+		}
+	} else {
+		if err := w.WriteString(*m.Side); err != nil {
+			return err
+		}
+	}
+
+	if err := w.WriteString("door_index"); err != nil {
+		return err
+	}
+	if err := w.WriteUint(uint64(m.DoorIndex)); err != nil {
+		return err
+	}
+
+	if err := w.WriteString("leaves"); err != nil {
+		return err
+	}
+	if err := w.WriteArrayHeader(len(m.Leaves)); err != nil {
+		return err
+	}
+	for _, v0 := range m.Leaves {
+		if err := v0.MarshalMsgpack(w); err != nil {
+			return err
+		}
+	}
+
+	if err := w.WriteString("blocked_leaf"); err != nil {
+		return err
+	}
+	if m.BlockedLeaf == nil {
+		if err := w.WriteNil(); err != nil {
+			return err
+		}
+	} else {
+		if err := m.BlockedLeaf.MarshalMsgpack(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *DoorState) UnmarshalMsgpack(r *msgpack.Reader) error {
+	h, err := r.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < h; i++ {
+		key, err := r.ReadString()
+		if err != nil {
+			return err
+		}
+
+		switch key {
+		case "open":
+			{
+				v, err := r.ReadBool()
+				if err != nil {
+					return err
+				}
+				m.Open = v
+			}
+		case "side":
+			if r.IsNil() {
+				if err := r.ReadNil(); err != nil {
+					return err
+				}
+				m.Side = nil
+				continue
+			}
+			m.Side = new(string)
+			{
+				v, err := r.ReadString()
+				if err != nil {
+					return err
+				}
+				*m.Side = v
+			}
+		case "door_index":
+			{
+				v, err := r.ReadUint()
+				if err != nil {
+					return err
+				}
+				m.DoorIndex = uint8(v)
+			}
+		case "leaves":
+			{
+				n0, err := r.ReadArrayHeader()
+				if err != nil {
+					return err
+				}
+				m.Leaves = make([]Leaf, n0)
+				for i0 := range m.Leaves {
+					if err := m.Leaves[i0].UnmarshalMsgpack(r); err != nil {
+						return err
+					}
+				}
+			}
+		case "blocked_leaf":
+			if r.IsNil() {
+				if err := r.ReadNil(); err != nil {
+					return err
+				}
+				m.BlockedLeaf = nil
+				continue
+			}
+			m.BlockedLeaf = new(Leaf)
+			if err := m.BlockedLeaf.UnmarshalMsgpack(r); err != nil {
+				return err
+			}
+		default:
+			if err := r.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (DoorState) Meta() message.Meta {
+	return message.Meta{Namespace: "doors", Identifier: "state", Bus: ""}
+}
+
+// OnDoorState registers a handler for DoorState messages.
+func OnDoorState(handler func(message.Incoming[DoorState])) *message.Subscription {
+	return message.RegisterHandler(handler)
+}
+
+type DoorCommand struct {
+	Open    bool     `msgpack:"open"`
+	Delay   *float64 `msgpack:"delay"`
+	Doors   []int32  `msgpack:"doors"`
+	Payload []byte   `msgpack:"payload" schema:"optional"`
+}
+
+func (m DoorCommand) MarshalMsgpack(w *msgpack.Writer) error {
+	if err := w.WriteMapHeader(4); err != nil {
+		return err
+	}
+
+	if err := w.WriteString("open"); err != nil {
+		return err
+	}
+	if err := w.WriteBool(m.Open); err != nil {
+		return err
+	}
+
+	if err := w.WriteString("delay"); err != nil {
+		return err
+	}
+	if m.Delay == nil {
+		if err := w.WriteNil(); err != nil {
+			return err
+		}
+	} else {
+		if err := w.WriteFloat64(*m.Delay); err != nil {
+			return err
+		}
+	}
+
+	if err := w.WriteString("doors"); err != nil {
+		return err
+	}
+	if err := w.WriteArrayHeader(len(m.Doors)); err != nil {
+		return err
+	}
+	for _, v0 := range m.Doors {
+		if err := w.WriteInt(int64(v0)); err != nil {
+			return err
+		}
+	}
+
+	if err := w.WriteString("payload"); err != nil {
+		return err
+	}
+	if m.Payload == nil {
+		if err := w.WriteNil(); err != nil {
+			return err
+		}
+	} else {
+		if err := w.WriteBinary(m.Payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *DoorCommand) UnmarshalMsgpack(r *msgpack.Reader) error {
+	h, err := r.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < h; i++ {
+		key, err := r.ReadString()
+		if err != nil {
+			return err
+		}
+
+		switch key {
+		case "open":
+			{
+				v, err := r.ReadBool()
+				if err != nil {
+					return err
+				}
+				m.Open = v
+			}
+		case "delay":
+			if r.IsNil() {
+				if err := r.ReadNil(); err != nil {
+					return err
+				}
+				m.Delay = nil
+				continue
+			}
+			m.Delay = new(float64)
+			{
+				v, err := r.ReadFloat()
+				if err != nil {
+					return err
+				}
+				*m.Delay = float64(v)
+			}
+		case "doors":
+			{
+				n0, err := r.ReadArrayHeader()
+				if err != nil {
+					return err
+				}
+				m.Doors = make([]int32, n0)
+				for i0 := range m.Doors {
+					{
+						v, err := r.ReadInt()
+						if err != nil {
+							return err
+						}
+						m.Doors[i0] = int32(v)
+					}
+				}
+			}
+		case "payload":
+			if r.IsNil() {
+				if err := r.ReadNil(); err != nil {
+					return err
+				}
+				m.Payload = nil
+				continue
+			}
+			{
+				v, err := r.ReadBinary()
+				if err != nil {
+					return err
+				}
+				m.Payload = v
+			}
+		default:
+			if err := r.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (DoorCommand) Meta() message.Meta {
+	return message.Meta{Namespace: "doors", Identifier: "command", Bus: "doors"}
+}
+
+// OnDoorCommand registers a handler for DoorCommand messages.
+func OnDoorCommand(handler func(message.Incoming[DoorCommand])) *message.Subscription {
+	return message.RegisterHandler(handler)
+}
+
+type Leaf struct {
+	Position float32 `msgpack:"position"`
+	Blocked  bool    `msgpack:"blocked"`
+}
+
+func (m Leaf) MarshalMsgpack(w *msgpack.Writer) error {
+	if err := w.WriteMapHeader(2); err != nil {
+		return err
+	}
+
+	if err := w.WriteString("position"); err != nil {
+		return err
+	}
+	if err := w.WriteFloat32(m.Position); err != nil {
+		return err
+	}
+
+	if err := w.WriteString("blocked"); err != nil {
+		return err
+	}
+	if err := w.WriteBool(m.Blocked); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Leaf) UnmarshalMsgpack(r *msgpack.Reader) error {
+	h, err := r.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < h; i++ {
+		key, err := r.ReadString()
+		if err != nil {
+			return err
+		}
+
+		switch key {
+		case "position":
+			{
+				v, err := r.ReadFloat()
+				if err != nil {
+					return err
+				}
+				m.Position = float32(v)
+			}
+		case "blocked":
+			{
+				v, err := r.ReadBool()
+				if err != nil {
+					return err
+				}
+				m.Blocked = v
+			}
+		default:
+			if err := r.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
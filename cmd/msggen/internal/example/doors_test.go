@@ -0,0 +1,108 @@
+package example_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oriolus-software/script-go/cmd/msggen/internal/example"
+	"github.com/oriolus-software/script-go/message"
+	"github.com/oriolus-software/script-go/msgpack"
+	"github.com/oriolus-software/script-go/schema"
+	"github.com/oriolus-software/script-go/scripttest"
+)
+
+func TestRoundTrip(t *testing.T) {
+	side := "left"
+	delay := 1.5
+
+	state := example.DoorState{
+		Open:        true,
+		Side:        &side,
+		DoorIndex:   2,
+		Leaves:      []example.Leaf{{Position: 0.5}, {Position: 1, Blocked: true}},
+		BlockedLeaf: &example.Leaf{Position: 1, Blocked: true},
+	}
+
+	data, err := msgpack.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got example.DoorState
+	if err := msgpack.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, state) {
+		t.Fatalf("got %+v, want %+v", got, state)
+	}
+
+	cmd := example.DoorCommand{Delay: &delay, Doors: []int32{-1, 3}}
+
+	data, err = msgpack.Marshal(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotCmd example.DoorCommand
+	if err := msgpack.Unmarshal(data, &gotCmd); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(gotCmd, cmd) {
+		t.Fatalf("got %+v, want %+v", gotCmd, cmd)
+	}
+}
+
+// reflected mirrors DoorState for the reflective encoder, with an extra
+// field the generated decoder has to skip.
+type reflected struct {
+	Open      bool              `msgpack:"open"`
+	Side      *string           `msgpack:"side"`
+	DoorIndex uint8             `msgpack:"door_index"`
+	Leaves    []map[string]any  `msgpack:"leaves"`
+	Extra     map[string]string `msgpack:"extra"`
+}
+
+func TestDecodeReflectiveEncoding(t *testing.T) {
+	data, err := msgpack.Marshal(reflected{
+		Open:      true,
+		DoorIndex: 4,
+		Leaves:    []map[string]any{{"position": 0.25, "blocked": true}},
+		Extra:     map[string]string{"a": "b"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got example.DoorState
+	if err := msgpack.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := example.DoorState{Open: true, DoorIndex: 4, Leaves: []example.Leaf{{Position: 0.25, Blocked: true}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestRegistrationHelper(t *testing.T) {
+	rt := scripttest.New(t)
+
+	var got []bool
+	sub := example.OnDoorState(func(in message.Incoming[example.DoorState]) {
+		got = append(got, in.Payload.Open)
+	})
+	defer sub.Unsubscribe()
+
+	rt.Deliver(example.DoorState{Open: true}, message.MessageSource{})
+	rt.Tick()
+
+	if len(got) != 1 || !got[0] {
+		t.Fatalf("handled %v, want [true]", got)
+	}
+}
+
+func TestMatchesSchema(t *testing.T) {
+	schema.Check(t, "doors.json", example.DoorState{}, example.DoorCommand{})
+}
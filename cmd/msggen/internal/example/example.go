@@ -0,0 +1,5 @@
+// Package example holds messages generated from doors.json. It keeps the
+// generator output compiling and is used by its tests.
+package example
+
+//go:generate go run ../.. -schema doors.json
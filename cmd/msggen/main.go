@@ -0,0 +1,63 @@
+// Command msggen generates Go message types from a schema file.
+//
+// The generated types implement message.Message and encode and decode
+// themselves without reflection. For every message an On<Name> function
+// registers a handler for it. Typical use is a go:generate directive next
+// to the schema:
+//
+//	//go:generate go run github.com/oriolus-software/script-go/cmd/msggen -schema messages.json
+//
+// See package schema for the schema format.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oriolus-software/script-go/schema"
+)
+
+func main() {
+	schemaPath := flag.String("schema", "", "path of the JSON schema")
+	pkg := flag.String("package", "", "package name of the generated file (default: $GOPACKAGE)")
+	out := flag.String("out", "", "path of the generated file (default: <schema>_gen.go)")
+	flag.Parse()
+
+	if *schemaPath == "" {
+		fmt.Fprintln(os.Stderr, "msggen: -schema is required")
+		os.Exit(2)
+	}
+
+	if *pkg == "" {
+		*pkg = os.Getenv("GOPACKAGE")
+	}
+
+	if *pkg == "" {
+		fmt.Fprintln(os.Stderr, "msggen: -package is required outside of go generate")
+		os.Exit(2)
+	}
+
+	if *out == "" {
+		*out = strings.TrimSuffix(*schemaPath, filepath.Ext(*schemaPath)) + "_gen.go"
+	}
+
+	s, err := schema.Load(*schemaPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "msggen: %v\n", err)
+		os.Exit(1)
+	}
+
+	src, err := generate(s, *pkg, filepath.Base(*schemaPath))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "msggen: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "msggen: %v\n", err)
+		os.Exit(1)
+	}
+}
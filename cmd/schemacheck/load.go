@@ -0,0 +1,307 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/oriolus-software/script-go/schema"
+)
+
+const messagePkg = "github.com/oriolus-software/script-go/message"
+
+// loadPackage type checks the package in dir and describes its message
+// types. Structs not referenced by a message are only included if want has
+// a struct of the same name.
+func loadPackage(dir string, want *schema.Schema) (*schema.Schema, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	exports, err := exportData(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := func(path string) (io.ReadCloser, error) {
+		file, ok := exports[path]
+		if !ok {
+			return nil, fmt.Errorf("no export data for %s", path)
+		}
+
+		return os.Open(file)
+	}
+
+	info := &types.Info{Types: make(map[ast.Expr]types.TypeAndValue)}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "gc", lookup)}
+	pkg, err := conf.Check(bp.ImportPath, fset, files, info)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &describer{
+		schema: &schema.Schema{},
+		types:  make(map[string]*types.Named),
+		metas:  metaLiterals(files, info),
+	}
+
+	structs := make(map[string]bool)
+	for _, st := range want.Structs {
+		structs[st.Name] = true
+	}
+
+	names := pkg.Scope().Names()
+	sort.Strings(names)
+
+	for _, name := range names {
+		tn, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok || tn.IsAlias() {
+			continue
+		}
+
+		named, ok := tn.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+
+		if _, ok := named.Underlying().(*types.Struct); !ok {
+			continue
+		}
+
+		if isMessage(named) || (tn.Exported() && structs[name]) {
+			if err := d.declare(named); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return d.schema, nil
+}
+
+// exportData returns the files holding the compiled export data of the
+// dependencies of the package in dir, keyed by import path. Importing them
+// is much faster than type checking their source.
+func exportData(dir string) (map[string]string, error) {
+	cmd := exec.Command("go", "list", "-e", "-export", "-deps", "-f", "{{.ImportPath}}={{.Export}}", ".")
+	cmd.Dir = dir
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list: %w", err)
+	}
+
+	exports := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		path, file, _ := strings.Cut(line, "=")
+		if file != "" {
+			exports[path] = file
+		}
+	}
+
+	return exports, nil
+}
+
+type describer struct {
+	schema *schema.Schema
+	types  map[string]*types.Named
+	metas  map[string]map[string]string
+}
+
+func isMessage(named *types.Named) bool {
+	obj, _, _ := types.LookupFieldOrMethod(named, false, named.Obj().Pkg(), "Meta")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return false
+	}
+
+	result, ok := sig.Results().At(0).Type().(*types.Named)
+	return ok && result.Obj().Name() == "Meta" && result.Obj().Pkg() != nil && result.Obj().Pkg().Path() == messagePkg
+}
+
+// metaLiterals collects the constant fields of message.Meta literals
+// returned by Meta methods, keyed by receiver type name.
+func metaLiterals(files []*ast.File, info *types.Info) map[string]map[string]string {
+	metas := make(map[string]map[string]string)
+
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Name.Name != "Meta" || fn.Recv == nil || fn.Body == nil || len(fn.Body.List) != 1 {
+				continue
+			}
+
+			ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+			if !ok || len(ret.Results) != 1 {
+				continue
+			}
+
+			lit, ok := ret.Results[0].(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+
+			fields := make(map[string]string)
+			for _, elt := range lit.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+
+				key, ok := kv.Key.(*ast.Ident)
+				value := info.Types[kv.Value].Value
+				if !ok || value == nil || value.Kind() != constant.String {
+					continue
+				}
+
+				fields[key.Name] = constant.StringVal(value)
+			}
+
+			metas[receiverName(fn.Recv.List[0].Type)] = fields
+		}
+	}
+
+	return metas
+}
+
+func receiverName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+
+	return ""
+}
+
+func (d *describer) declare(named *types.Named) error {
+	name := named.Obj().Name()
+	if prev, ok := d.types[name]; ok {
+		if prev != named {
+			return fmt.Errorf("type %s is declared by %s and %s", name, prev.Obj().Pkg().Path(), named.Obj().Pkg().Path())
+		}
+
+		return nil
+	}
+	d.types[name] = named
+
+	fields, err := schema.FieldsOf(checkedType{named}, func(t schema.GoType) error {
+		return d.declare(types.Unalias(t.(checkedType).t).(*types.Named))
+	})
+	if err != nil {
+		return err
+	}
+
+	if !isMessage(named) {
+		d.schema.Structs = append(d.schema.Structs, schema.Struct{Name: name, Fields: fields})
+		return nil
+	}
+
+	meta, ok := d.metas[name]
+	if !ok || meta["Namespace"] == "" || meta["Identifier"] == "" {
+		return fmt.Errorf("cannot determine the meta of %s, Meta must return a message.Meta literal with constant fields", name)
+	}
+
+	d.schema.Messages = append(d.schema.Messages, schema.Message{
+		Name:       name,
+		Namespace:  meta["Namespace"],
+		Identifier: meta["Identifier"],
+		Bus:        meta["Bus"],
+		Fields:     fields,
+	})
+
+	return nil
+}
+
+// checkedType implements schema.GoType for type checked types.
+type checkedType struct {
+	t types.Type
+}
+
+func (c checkedType) Kind() string {
+	switch u := c.t.Underlying().(type) {
+	case *types.Basic:
+		// Typ names byte and rune by the types they alias.
+		return types.Typ[u.Kind()].Name()
+	case *types.Pointer:
+		return "pointer"
+	case *types.Slice:
+		return "slice"
+	case *types.Array:
+		return "array"
+	case *types.Struct:
+		return "struct"
+	}
+
+	return ""
+}
+
+func (c checkedType) Elem() schema.GoType {
+	switch u := c.t.Underlying().(type) {
+	case *types.Pointer:
+		return checkedType{u.Elem()}
+	case *types.Slice:
+		return checkedType{u.Elem()}
+	case *types.Array:
+		return checkedType{u.Elem()}
+	}
+
+	return nil
+}
+
+func (c checkedType) Name() string {
+	if named, ok := types.Unalias(c.t).(*types.Named); ok {
+		return named.Obj().Name()
+	}
+
+	return ""
+}
+
+func (c checkedType) NumField() int {
+	return c.t.Underlying().(*types.Struct).NumFields()
+}
+
+func (c checkedType) Field(i int) schema.GoField {
+	st := c.t.Underlying().(*types.Struct)
+	field := st.Field(i)
+
+	return schema.GoField{
+		Name:     field.Name(),
+		Exported: field.Exported(),
+		Tag:      reflect.StructTag(st.Tag(i)),
+		Type:     checkedType{field.Type()},
+	}
+}
+
+func (c checkedType) String() string {
+	return c.t.String()
+}
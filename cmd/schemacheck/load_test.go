@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/oriolus-software/script-go/schema"
+)
+
+func TestLoadPackage(t *testing.T) {
+	want, err := schema.Load("../msggen/internal/example/doors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := loadPackage("../msggen/internal/example", want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if mismatches := schema.Compare(want, got); len(mismatches) > 0 {
+		t.Fatalf("unexpected mismatches:\n%s", schema.Format(mismatches))
+	}
+
+	if len(got.Messages) != 2 || len(got.Structs) != 1 {
+		t.Fatalf("got %d messages and %d structs, want 2 and 1", len(got.Messages), len(got.Structs))
+	}
+
+	for _, m := range got.Messages {
+		if m.Name == "DoorCommand" && m.Bus != "doors" {
+			t.Errorf("DoorCommand bus = %q, want doors", m.Bus)
+		}
+	}
+
+	want.Structs[0].Fields[0].Type = "f64"
+	if mismatches := schema.Compare(want, got); len(mismatches) != 1 {
+		t.Fatalf("got %v, want one mismatch", mismatches)
+	}
+}
@@ -0,0 +1,70 @@
+// Command schemacheck compares the message types of a Go package with a
+// schema exported by scripts written in other languages.
+//
+//	go run github.com/oriolus-software/script-go/cmd/schemacheck -schema export.json ./doors
+//
+// Every type with a Meta method returning a constant message.Meta is
+// checked, together with the structs it references. Exported structs that
+// share their name with a struct of the schema are checked as well. Field
+// name, type and optionality mismatches are printed one per line and make
+// the command exit with status 1.
+//
+// Numbers must have the same type on both sides, since messages are usually
+// sent both ways. With -direction receive or -direction send, a wider type
+// on the receiving side is accepted, see schema.Direction.
+//
+// The schema is JSON or msgpack in the format of package schema. In tests,
+// schema.Check does the same for a list of Go values.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/oriolus-software/script-go/schema"
+)
+
+var directions = map[string]schema.Direction{
+	"both":    schema.Both,
+	"receive": schema.Receive,
+	"send":    schema.Send,
+}
+
+func main() {
+	schemaPath := flag.String("schema", "", "path of the JSON or msgpack schema export")
+	directionName := flag.String("direction", "both", "whether the Go package sends or receives the messages: both, send or receive")
+	flag.Parse()
+
+	direction, ok := directions[*directionName]
+	if *schemaPath == "" || flag.NArg() > 1 || !ok {
+		fmt.Fprintln(os.Stderr, "usage: schemacheck -schema <file> [-direction both|receive|send] [package directory]")
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+
+	want, err := schema.Load(*schemaPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "schemacheck: %v\n", err)
+		os.Exit(1)
+	}
+
+	got, err := loadPackage(dir, want)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "schemacheck: %v\n", err)
+		os.Exit(1)
+	}
+
+	mismatches := schema.CompareDirection(want, got, direction)
+	for _, m := range mismatches {
+		fmt.Println(m)
+	}
+
+	if len(mismatches) > 0 {
+		os.Exit(1)
+	}
+}
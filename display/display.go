@@ -0,0 +1,272 @@
+// Package display animates text on matrix displays such as destination
+// signs: marquee scrolling, blinking and alternating pages.
+//
+// Every line renders its text once into a texture of its own and copies the
+// visible part onto the display with DrawScriptTexture, so scrolling text
+// is clipped to its line. A line is only drawn again when its visible frame
+// changes.
+package display
+
+import (
+	"math"
+
+	"github.com/oriolus-software/script-go/font"
+	"github.com/oriolus-software/script-go/lmath"
+	"github.com/oriolus-software/script-go/texture"
+	"github.com/oriolus-software/script-go/time"
+)
+
+type Line struct {
+	Font *font.BitmapFont
+	// Rect is the area of the line on the display, end exclusive.
+	Rect lmath.Rectangle
+	// Pages are shown one after another for PageDuration seconds each. With
+	// a PageDuration of 0 only the first page is shown.
+	Pages         []string
+	PageDuration  float64
+	Align         font.Align
+	Color         texture.Color
+	LetterSpacing uint32
+	// Speed scrolls text that is wider than Rect from right to left, in
+	// pixels per second.
+	Speed float64
+	// ScrollAlways scrolls text that fits Rect as well.
+	ScrollAlways bool
+	// BlinkPeriod shows the text for the first half of every period and
+	// hides it for the second half. 0 disables blinking.
+	BlinkPeriod float64
+
+	elapsed  float64
+	shown    frame
+	rendered bool
+	cache    map[rendering]texture.Texture
+}
+
+// rendering identifies rendered text.
+type rendering struct {
+	text          string
+	color         texture.Color
+	font          *font.BitmapFont
+	letterSpacing uint32
+}
+
+// frame is what a line shows at a point in time.
+type frame struct {
+	rendering
+	x       int
+	visible bool
+}
+
+// Reset restarts the animation of the line.
+func (l *Line) Reset() {
+	l.elapsed = 0
+	l.rendered = false
+}
+
+type Display struct {
+	texture    texture.Texture
+	background texture.Color
+	lines      []*Line
+	// retired holds rendered texts that are no longer needed but may still
+	// be the source of draws that were not flushed yet.
+	retired []texture.Texture
+}
+
+// New returns a display drawing onto t. Lines are cleared with background
+// before they are drawn.
+func New(t texture.Texture, background texture.Color) *Display {
+	return &Display{texture: t, background: background}
+}
+
+// Add adds a line to the display. The returned line can be changed while
+// the display runs.
+func (d *Display) Add(line Line) *Line {
+	l := &line
+	d.lines = append(d.lines, l)
+	return l
+}
+
+// Update advances the animations by the duration of the current tick.
+func (d *Display) Update() {
+	d.Advance(time.Delta64())
+}
+
+// Advance advances the animations by seconds and draws the lines whose
+// frame changed. It reports whether anything was drawn. The actions are
+// flushed with all other textures at the end of the tick, or right away if
+// rendered texts that are no longer shown have to be disposed.
+func (d *Display) Advance(seconds float64) bool {
+	drawn := false
+
+	for _, l := range d.lines {
+		l.elapsed += seconds
+
+		f := l.frame()
+		if l.rendered && f == l.shown {
+			continue
+		}
+
+		d.draw(l, f)
+		l.shown, l.rendered = f, true
+		drawn = true
+	}
+
+	if len(d.retired) > 0 {
+		// The host has to copy from the retired textures before they go.
+		d.texture.Flush()
+		for _, t := range d.retired {
+			t.Dispose()
+		}
+		d.retired = d.retired[:0]
+	}
+
+	return drawn
+}
+
+// Dispose disposes the textures holding the rendered text of the lines. The
+// display texture is flushed first, since it may still copy from them.
+func (d *Display) Dispose() {
+	d.texture.Flush()
+
+	for _, t := range d.retired {
+		t.Dispose()
+	}
+	d.retired = nil
+
+	for _, l := range d.lines {
+		for key, t := range l.cache {
+			t.Dispose()
+			delete(l.cache, key)
+		}
+	}
+}
+
+// rendering returns how the line renders text with its current settings.
+func (l *Line) rendering(text string) rendering {
+	return rendering{text: text, color: l.Color, font: l.Font, letterSpacing: l.LetterSpacing}
+}
+
+func (l *Line) frame() frame {
+	if len(l.Pages) == 0 {
+		return frame{}
+	}
+
+	page, pageElapsed := 0, l.elapsed
+	if len(l.Pages) > 1 && l.PageDuration > 0 {
+		page = int(l.elapsed/l.PageDuration) % len(l.Pages)
+		pageElapsed = math.Mod(l.elapsed, l.PageDuration)
+	}
+
+	f := frame{rendering: l.rendering(l.Pages[page]), visible: true}
+	if l.BlinkPeriod > 0 {
+		f.visible = math.Mod(l.elapsed, l.BlinkPeriod) < l.BlinkPeriod/2
+	}
+
+	width := textWidth(l.Font, f.text, l.LetterSpacing)
+	rectWidth := int(l.Rect.End.X) - int(l.Rect.Start.X)
+
+	switch {
+	case l.Speed > 0 && (l.ScrollAlways || width > rectWidth):
+		// Nothing scrolls through an empty line.
+		if cycle := rectWidth + width; cycle > 0 {
+			f.x = rectWidth - int(pageElapsed*l.Speed)%cycle
+		}
+	case l.Align == font.AlignCenter:
+		f.x = (rectWidth - width) / 2
+	case l.Align == font.AlignRight:
+		f.x = rectWidth - width
+	}
+
+	return f
+}
+
+func (d *Display) draw(l *Line, f frame) {
+	d.texture.DrawRect(l.Rect.Start, l.Rect.End, d.background)
+
+	if !f.visible || f.text == "" {
+		return
+	}
+
+	src, ok := d.rendered(l, f.rendering)
+	if !ok {
+		return
+	}
+
+	width := src.Bounds().Dx()
+	rectWidth := int(l.Rect.End.X) - int(l.Rect.Start.X)
+	height := min(src.Bounds().Dy(), int(l.Rect.End.Y)-int(l.Rect.Start.Y))
+
+	// The part of the text inside the line.
+	start, end := max(0, -f.x), min(width, rectWidth-f.x)
+	if start >= end || height <= 0 {
+		return
+	}
+
+	x := int(l.Rect.Start.X) + f.x + start
+	y := l.Rect.Start.Y
+
+	d.texture.DrawScriptTexture(src, texture.DrawTextureOptions{
+		SourceRect: lmath.Rectangle{
+			Start: lmath.UVec2{X: uint(start)},
+			End:   lmath.UVec2{X: uint(end), Y: uint(height)},
+		},
+		TargetRect: lmath.Rectangle{
+			Start: lmath.UVec2{X: uint(x), Y: y},
+			End:   lmath.UVec2{X: uint(x + end - start), Y: y + uint(height)},
+		},
+	})
+}
+
+// rendered returns the texture holding the rendered text, rendering it on
+// first use. Textures of texts that are no longer one of the line's pages or
+// were rendered with other settings are retired, Advance disposes them.
+func (d *Display) rendered(l *Line, r rendering) (texture.Texture, bool) {
+	if t, ok := l.cache[r]; ok {
+		return t, true
+	}
+
+	if l.cache == nil {
+		l.cache = make(map[rendering]texture.Texture)
+	}
+
+	for cached, t := range l.cache {
+		if cached != l.rendering(cached.text) || !contains(l.Pages, cached.text) {
+			d.retired = append(d.retired, t)
+			delete(l.cache, cached)
+		}
+	}
+
+	width := textWidth(r.font, r.text, r.letterSpacing)
+	if width <= 0 || r.font.VerticalSize <= 0 {
+		return 0, false
+	}
+
+	t := texture.Create(texture.CreationOptions{Width: width, Height: int(r.font.VerticalSize)})
+	t.Clear(d.background)
+	t.DrawText(&texture.DrawTextOptions{
+		Font:          r.font.ContentId,
+		Text:          r.font.Normalize(r.text),
+		LetterSpacing: r.letterSpacing,
+		FullColor:     &r.color,
+	})
+	t.Flush()
+
+	l.cache[r] = t
+	return t, true
+}
+
+// textWidth measures text, skipping characters the font cannot draw.
+func textWidth(f *font.BitmapFont, text string, letterSpacing uint32) int {
+	width, _ := f.Measure(text, int(letterSpacing))
+	return width
+}
+
+func contains(pages []string, text string) bool {
+	for _, page := range pages {
+		if page == text {
+			return true
+		}
+	}
+
+	return false
+}
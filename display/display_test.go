@@ -0,0 +1,239 @@
+package display_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oriolus-software/script-go/assets"
+	"github.com/oriolus-software/script-go/display"
+	"github.com/oriolus-software/script-go/font"
+	"github.com/oriolus-software/script-go/lmath"
+	"github.com/oriolus-software/script-go/scripttest"
+	"github.com/oriolus-software/script-go/texture"
+)
+
+// testFont has 5 pixel wide capitals, a 2 pixel space and 1 pixel between
+// letters.
+func testFont() *font.BitmapFont {
+	letters := map[string]font.FontLetter{" ": {Character: " ", Width: 2}}
+	for _, r := range "ABCDEFGHIJKLMNOPQRSTUVWXYZ" {
+		letters[string(r)] = font.FontLetter{Character: string(r), Width: 5}
+	}
+
+	return &font.BitmapFont{
+		BitmapFontProperties: font.BitmapFontProperties{HorizontalDistance: 1, VerticalSize: 7, Letters: letters},
+		ContentId:            assets.ContentId{UserId: 1, SubId: 1},
+	}
+}
+
+var lineRect = lmath.Rectangle{End: lmath.UVec2{X: 40, Y: 7}}
+
+// copies returns the target x ranges of the DrawScriptTexture actions
+// flushed to tex since the last call.
+func copies(t *testing.T, rt *scripttest.Runtime, tex texture.Texture, seen *int) [][2]int64 {
+	t.Helper()
+	tex.Flush()
+
+	actions := rt.Texture(tex).Actions
+	var ranges [][2]int64
+	for _, action := range actions[*seen:] {
+		d, ok := action.(map[string]any)["DrawScriptTexture"].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		target := d["options"].(map[string]any)["TargetRect"].(map[string]any)
+		ranges = append(ranges, [2]int64{target["start"].([]any)[0].(int64), target["end"].([]any)[0].(int64)})
+	}
+	*seen = len(actions)
+
+	return ranges
+}
+
+func TestStaticLine(t *testing.T) {
+	rt := scripttest.New(t)
+	tex := texture.Create(texture.CreationOptions{Width: 40, Height: 7})
+
+	d := display.New(tex, texture.Color{A: 255})
+	d.Add(display.Line{Font: testFont(), Rect: lineRect, Pages: []string{"HI"}, Align: font.AlignCenter})
+
+	seen := 0
+	if !d.Advance(0) {
+		t.Fatal("first frame was not drawn")
+	}
+
+	if got := copies(t, rt, tex, &seen); !reflect.DeepEqual(got, [][2]int64{{14, 25}}) {
+		t.Fatalf("got %v", got)
+	}
+
+	if d.Advance(5) {
+		t.Fatal("unchanged frame was drawn again")
+	}
+}
+
+func TestMarquee(t *testing.T) {
+	rt := scripttest.New(t)
+	tex := texture.Create(texture.CreationOptions{Width: 40, Height: 7})
+
+	d := display.New(tex, texture.Color{A: 255})
+	d.Add(display.Line{Font: testFont(), Rect: lineRect, Pages: []string{"HELLO WORLD"}, Speed: 10})
+
+	seen := 0
+	d.Advance(0)
+	if got := copies(t, rt, tex, &seen); got != nil {
+		t.Fatalf("text is visible before scrolling in: %v", got)
+	}
+
+	if d.Advance(0.05) {
+		t.Fatal("frame changed before moving a pixel")
+	}
+
+	d.Advance(0.05)
+	if got := copies(t, rt, tex, &seen); !reflect.DeepEqual(got, [][2]int64{{39, 40}}) {
+		t.Fatalf("got %v", got)
+	}
+
+	// After 73 pixels the end of the text is at 29.
+	d.Advance(7.25)
+	if got := copies(t, rt, tex, &seen); !reflect.DeepEqual(got, [][2]int64{{0, 29}}) {
+		t.Fatalf("got %v", got)
+	}
+
+	// 62 pixels of text plus 40 of the line wrap around after 10.2 seconds.
+	d.Advance(2.9)
+	if got := copies(t, rt, tex, &seen); got != nil {
+		t.Fatalf("text did not wrap around: %v", got)
+	}
+}
+
+func TestBlinkAndPages(t *testing.T) {
+	rt := scripttest.New(t)
+	tex := texture.Create(texture.CreationOptions{Width: 40, Height: 7})
+
+	d := display.New(tex, texture.Color{A: 255})
+	line := d.Add(display.Line{
+		Font:         testFont(),
+		Rect:         lineRect,
+		Pages:        []string{"A", "BB"},
+		PageDuration: 2,
+		BlinkPeriod:  1,
+	})
+
+	seen := 0
+	rt.SetDelta(0.6)
+	d.Update()
+	if got := copies(t, rt, tex, &seen); got != nil {
+		t.Fatalf("blinking text is visible: %v", got)
+	}
+
+	if d.Advance(0.1) {
+		t.Fatal("hidden frame was drawn again")
+	}
+
+	d.Advance(0.4)
+	if got := copies(t, rt, tex, &seen); !reflect.DeepEqual(got, [][2]int64{{0, 5}}) {
+		t.Fatalf("got %v", got)
+	}
+
+	d.Advance(1)
+	if got := copies(t, rt, tex, &seen); !reflect.DeepEqual(got, [][2]int64{{0, 11}}) {
+		t.Fatalf("second page: got %v", got)
+	}
+
+	line.Pages = []string{"C"}
+	line.BlinkPeriod = 0
+	line.Reset()
+	d.Advance(0)
+	if got := copies(t, rt, tex, &seen); !reflect.DeepEqual(got, [][2]int64{{0, 5}}) {
+		t.Fatalf("changed pages: got %v", got)
+	}
+}
+
+func TestRetiredTextIsFlushedFirst(t *testing.T) {
+	rt := scripttest.New(t)
+	tex := texture.Create(texture.CreationOptions{Width: 40, Height: 7})
+
+	d := display.New(tex, texture.Color{A: 255})
+	line := d.Add(display.Line{Font: testFont(), Rect: lineRect, Pages: []string{"A"}})
+
+	d.Advance(0)
+	line.Pages = []string{"B"}
+	d.Advance(0)
+
+	// The copy of A was queued before A was retired, it has to reach the host
+	// before A is disposed.
+	var handles []int64
+	for _, action := range rt.Texture(tex).Actions {
+		if d, ok := action.(map[string]any)["DrawScriptTexture"].(map[string]any); ok {
+			handles = append(handles, d["handle"].(int64))
+		}
+	}
+
+	if len(handles) != 2 {
+		t.Fatalf("got %d flushed copies, want 2", len(handles))
+	}
+
+	if !rt.Texture(texture.Texture(handles[0])).Disposed {
+		t.Fatal("retired text was not disposed")
+	}
+
+	if rt.Texture(texture.Texture(handles[1])).Disposed {
+		t.Fatal("shown text was disposed")
+	}
+}
+
+func TestScrollEmptyLine(t *testing.T) {
+	scripttest.New(t)
+	tex := texture.Create(texture.CreationOptions{Width: 40, Height: 7})
+
+	d := display.New(tex, texture.Color{A: 255})
+	d.Add(display.Line{Font: testFont(), Pages: []string{"ß"}, Speed: 10, ScrollAlways: true})
+
+	d.Advance(1)
+}
+
+func TestLetterSpacingChange(t *testing.T) {
+	rt := scripttest.New(t)
+	tex := texture.Create(texture.CreationOptions{Width: 40, Height: 7})
+
+	d := display.New(tex, texture.Color{A: 255})
+	line := d.Add(display.Line{Font: testFont(), Rect: lineRect, Pages: []string{"AB"}})
+
+	seen := 0
+	d.Advance(0)
+	if got := copies(t, rt, tex, &seen); !reflect.DeepEqual(got, [][2]int64{{0, 11}}) {
+		t.Fatalf("got %v", got)
+	}
+
+	line.LetterSpacing = 2
+	if !d.Advance(0) {
+		t.Fatal("changed letter spacing was not drawn")
+	}
+
+	if got := copies(t, rt, tex, &seen); !reflect.DeepEqual(got, [][2]int64{{0, 13}}) {
+		t.Fatalf("text was not rendered again: got %v", got)
+	}
+}
+
+func TestDisposeFlushesFirst(t *testing.T) {
+	rt := scripttest.New(t)
+	tex := texture.Create(texture.CreationOptions{Width: 40, Height: 7})
+
+	d := display.New(tex, texture.Color{A: 255})
+	d.Add(display.Line{Font: testFont(), Rect: lineRect, Pages: []string{"A"}})
+
+	d.Advance(0)
+	d.Dispose()
+
+	// Nothing flushed the display texture but Dispose.
+	flushed := 0
+	for _, action := range rt.Texture(tex).Actions {
+		if _, ok := action.(map[string]any)["DrawScriptTexture"]; ok {
+			flushed++
+		}
+	}
+
+	if flushed != 1 {
+		t.Fatalf("expected the copy to be flushed before disposing, got %d copies", flushed)
+	}
+}
@@ -0,0 +1,23 @@
+//go:build !wasm
+
+package env
+
+import (
+	"github.com/oriolus-software/script-go/internal/host"
+)
+
+func isRC() bool {
+	return host.Current.Env.IsRC
+}
+
+func moduleSlotIndex() int {
+	return host.Current.Env.ModuleSlotIndex
+}
+
+func moduleSlotCockpitIndex() int {
+	return host.Current.Env.ModuleSlotCockpitIndex
+}
+
+func moduleSlotIndexInClassGroup() int {
+	return host.Current.Env.ModuleSlotIndexInClassGroup
+}
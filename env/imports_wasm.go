@@ -0,0 +1,19 @@
+//go:build wasm
+
+package env
+
+//go:wasm-module env
+//export is_rc
+func isRC() bool
+
+//go:wasm-module env
+//export module_slot_index
+func moduleSlotIndex() int
+
+//go:wasm-module env
+//export module_slot_cockpit_index
+func moduleSlotCockpitIndex() int
+
+//go:wasm-module env
+//export module_slot_index_in_class_group
+func moduleSlotIndexInClassGroup() int
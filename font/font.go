@@ -1,6 +1,8 @@
 package font
 
 import (
+	"fmt"
+
 	"github.com/oriolus-software/script-go/assets"
 	"github.com/oriolus-software/script-go/internal/ffi"
 )
@@ -8,6 +10,8 @@ import (
 type BitmapFont struct {
 	BitmapFontProperties
 	ContentId assets.ContentId
+	// Fallback is the letter drawn for characters the font does not have.
+	Fallback string
 }
 
 // / Properties of a bitmap font.
@@ -52,20 +56,15 @@ func LoadBitmapFontProperties(contentId assets.ContentId) (*BitmapFont, bool) {
 	return font, true
 }
 
-func (font *BitmapFont) TextLen(text string, letterSpacing int) int {
+// TextLen asks the host for the width of text. Measure computes the same
+// without a host call, TextLen is kept to verify it. An error is returned if
+// the host does not know the font or one of the characters.
+func (font *BitmapFont) TextLen(text string, letterSpacing int) (int, error) {
 	ret := textLen(ffi.Serialize(font.ContentId).ToPacked(), ffi.Serialize(text).ToPacked(), letterSpacing)
 
 	if ret == -1 {
-		panic("bitmap font properties should be loaded and text_len should return valid value")
+		return 0, fmt.Errorf("host cannot measure %q in font %v", text, font.ContentId)
 	}
 
-	return ret
+	return ret, nil
 }
-
-//go:wasm-module font
-//export bitmap_font_properties
-func bitmapFontProperties(font uint64) uint64
-
-//go:wasm-module font
-//export text_len
-func textLen(font, text uint64, letterSpacing int) int
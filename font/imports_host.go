@@ -0,0 +1,28 @@
+//go:build !wasm
+
+package font
+
+import (
+	"github.com/oriolus-software/script-go/assets"
+	"github.com/oriolus-software/script-go/internal/ffi"
+	"github.com/oriolus-software/script-go/internal/host"
+)
+
+func bitmapFontProperties(font uint64) uint64 {
+	props, ok := host.Current.BitmapFont(ffi.Deserialize[host.ContentId](font))
+	if !ok {
+		return 0
+	}
+
+	return ffi.Serialize(props).ToPacked()
+}
+
+func textLen(font, text uint64, letterSpacing int) int {
+	return host.Current.TextLen(ffi.Deserialize[host.ContentId](font), ffi.Deserialize[string](text), letterSpacing)
+}
+
+func init() {
+	host.OnReset(func() {
+		bitmapFontCache = make(map[assets.ContentId]*BitmapFont)
+	})
+}
@@ -0,0 +1,11 @@
+//go:build wasm
+
+package font
+
+//go:wasm-module font
+//export bitmap_font_properties
+func bitmapFontProperties(font uint64) uint64
+
+//go:wasm-module font
+//export text_len
+func textLen(font, text uint64, letterSpacing int) int
@@ -0,0 +1,240 @@
+package font
+
+import (
+	"strings"
+
+	"github.com/oriolus-software/script-go/lmath"
+	"github.com/oriolus-software/script-go/texture"
+)
+
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignCenter
+	AlignRight
+	// AlignJustify stretches wrapped lines to the full width. The last line
+	// of a paragraph is aligned left.
+	AlignJustify
+)
+
+type LayoutOptions struct {
+	// Rect is the area the text is laid out in, end exclusive.
+	Rect          lmath.Rectangle
+	Align         Align
+	LetterSpacing uint32
+	// LineSpacing is added to the font's VerticalSize between lines.
+	LineSpacing int
+	// Wrap breaks lines between words to fit the width of Rect. Words wider
+	// than Rect are broken between characters. Without Wrap, only "\n"
+	// starts a new line.
+	Wrap bool
+	// Ellipsis is appended to text cut off at the end of Rect, e.g. "...".
+	Ellipsis string
+}
+
+// Run is a piece of text drawn at a position.
+type Run struct {
+	Text    string
+	TopLeft lmath.IVec2
+	Width   int
+}
+
+// Layout is text laid out by BitmapFont.Layout.
+type Layout struct {
+	Font          *BitmapFont
+	LetterSpacing uint32
+	Runs          []Run
+	// Lines is the number of visible lines.
+	Lines int
+	// Truncated reports whether text was cut off to fit Rect.
+	Truncated bool
+}
+
+type layoutLine struct {
+	text    string
+	justify bool
+}
+
+// Layout positions text inside opts.Rect. Widths are computed from the
+// font's letters without calling the host.
+func (f *BitmapFont) Layout(text string, opts LayoutOptions) *Layout {
+	l := &Layout{Font: f, LetterSpacing: opts.LetterSpacing}
+
+	spacing := int(opts.LetterSpacing)
+	maxWidth := int(opts.Rect.End.X) - int(opts.Rect.Start.X)
+	height := int(opts.Rect.End.Y) - int(opts.Rect.Start.Y)
+	lineHeight := int(f.VerticalSize) + opts.LineSpacing
+
+	maxLines := 0
+	if height >= int(f.VerticalSize) {
+		maxLines = 1
+		if lineHeight > 0 {
+			maxLines += (height - int(f.VerticalSize)) / lineHeight
+		}
+	}
+
+	var lines []layoutLine
+	for _, paragraph := range strings.Split(text, "\n") {
+		if opts.Wrap {
+			lines = append(lines, f.wrap(paragraph, maxWidth, spacing)...)
+		} else {
+			lines = append(lines, layoutLine{text: paragraph})
+		}
+	}
+
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		l.Truncated = true
+
+		if maxLines > 0 {
+			last := &lines[maxLines-1]
+			last.text = f.truncate(last.text, opts.Ellipsis, maxWidth, spacing, true)
+			last.justify = false
+		}
+	}
+
+	for i, line := range lines {
+		if f.width(line.text, spacing) > maxWidth {
+			line.text = f.truncate(line.text, opts.Ellipsis, maxWidth, spacing, false)
+			l.Truncated = true
+		}
+
+		y := int(opts.Rect.Start.Y) + i*lineHeight
+		l.place(line, opts, y, maxWidth)
+	}
+
+	l.Lines = len(lines)
+	return l
+}
+
+// wrap breaks a paragraph into lines of at most maxWidth pixels.
+func (f *BitmapFont) wrap(paragraph string, maxWidth, spacing int) []layoutLine {
+	var lines []layoutLine
+	line := ""
+
+	for _, word := range strings.Fields(paragraph) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+
+		if f.width(candidate, spacing) <= maxWidth {
+			line = candidate
+			continue
+		}
+
+		if line != "" {
+			lines = append(lines, layoutLine{text: line, justify: true})
+		}
+
+		for f.width(word, spacing) > maxWidth {
+			n := f.fit(word, maxWidth, spacing)
+			lines = append(lines, layoutLine{text: word[:n]})
+			word = word[n:]
+		}
+		line = word
+	}
+
+	return append(lines, layoutLine{text: line})
+}
+
+// truncate shortens text until it fits maxWidth together with ellipsis.
+// Unless force is set, text that already fits is returned unchanged.
+func (f *BitmapFont) truncate(text, ellipsis string, maxWidth, spacing int, force bool) string {
+	if !force && f.width(text, spacing) <= maxWidth {
+		return text
+	}
+
+	for text != "" && f.width(text+ellipsis, spacing) > maxWidth {
+		text = strings.TrimRight(text[:len(text)-lastClusterLen(text)], " ")
+	}
+
+	if f.width(text+ellipsis, spacing) > maxWidth {
+		return text
+	}
+
+	return text + ellipsis
+}
+
+// fit returns the byte length of the longest prefix of text that fits
+// maxWidth, but at least one character.
+func (f *BitmapFont) fit(text string, maxWidth, spacing int) int {
+	n := clusterLen(text)
+	for n < len(text) {
+		size := clusterLen(text[n:])
+		if f.width(text[:n+size], spacing) > maxWidth {
+			break
+		}
+		n += size
+	}
+
+	return n
+}
+
+func (l *Layout) place(line layoutLine, opts LayoutOptions, y, maxWidth int) {
+	f, spacing := l.Font, int(opts.LetterSpacing)
+	x := int(opts.Rect.Start.X)
+	width := f.width(line.text, spacing)
+
+	switch opts.Align {
+	case AlignCenter:
+		x += (maxWidth - width) / 2
+	case AlignRight:
+		x += maxWidth - width
+	case AlignJustify:
+		words := strings.Fields(line.text)
+		if line.justify && len(words) > 1 {
+			l.justify(words, x, y, maxWidth, spacing)
+			return
+		}
+	}
+
+	if line.text != "" {
+		l.Runs = append(l.Runs, Run{Text: f.Normalize(line.text), TopLeft: lmath.IVec2{X: x, Y: y}, Width: width})
+	}
+}
+
+// justify places every word as a run, spreading the free space evenly
+// between the words.
+func (l *Layout) justify(words []string, x, y, maxWidth, spacing int) {
+	widths := make([]int, len(words))
+	free := maxWidth
+	for i, word := range words {
+		widths[i] = l.Font.width(word, spacing)
+		free -= widths[i]
+	}
+
+	gaps := len(words) - 1
+	for i, word := range words {
+		l.Runs = append(l.Runs, Run{Text: l.Font.Normalize(word), TopLeft: lmath.IVec2{X: x, Y: y}, Width: widths[i]})
+
+		if i < gaps {
+			gap := free / gaps
+			if i < free%gaps {
+				gap++
+			}
+			x += widths[i] + gap
+		}
+	}
+}
+
+// Draw draws every run onto t. style provides the color and alpha mode,
+// its font, text, position and letter spacing are replaced.
+func (l *Layout) Draw(t texture.Texture, style texture.DrawTextOptions) {
+	for _, run := range l.Runs {
+		opts := style
+		opts.Font = l.Font.ContentId
+		opts.Text = run.Text
+		opts.TopLeft = run.TopLeft
+		opts.LetterSpacing = l.LetterSpacing
+		t.DrawText(&opts)
+	}
+}
+
+// width returns the width of text in pixels. Characters the font cannot
+// draw are skipped.
+func (f *BitmapFont) width(text string, letterSpacing int) int {
+	width, _ := f.Measure(text, letterSpacing)
+	return width
+}
@@ -0,0 +1,151 @@
+package font_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oriolus-software/script-go/assets"
+	"github.com/oriolus-software/script-go/font"
+	"github.com/oriolus-software/script-go/lmath"
+	"github.com/oriolus-software/script-go/scripttest"
+	"github.com/oriolus-software/script-go/texture"
+)
+
+// testFont has 5 pixel wide capitals and umlauts, a 2 pixel space and a 1
+// pixel dot, separated by 1 pixel.
+func testFont() *font.BitmapFont {
+	letters := map[string]font.FontLetter{
+		" ": {Character: " ", Width: 2},
+		".": {Character: ".", Width: 1},
+	}
+
+	for _, r := range "ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÜ" {
+		letters[string(r)] = font.FontLetter{Character: string(r), Width: 5}
+	}
+
+	return &font.BitmapFont{
+		BitmapFontProperties: font.BitmapFontProperties{
+			HorizontalDistance: 1,
+			VerticalSize:       7,
+			Letters:            letters,
+		},
+		ContentId: assets.ContentId{UserId: 1, SubId: 1},
+	}
+}
+
+func rect(x0, y0, x1, y1 uint) lmath.Rectangle {
+	return lmath.Rectangle{Start: lmath.UVec2{X: x0, Y: y0}, End: lmath.UVec2{X: x1, Y: y1}}
+}
+
+func run(text string, x, y, width int) font.Run {
+	return font.Run{Text: text, TopLeft: lmath.IVec2{X: x, Y: y}, Width: width}
+}
+
+func TestLayoutAlign(t *testing.T) {
+	f := testFont()
+
+	tests := []struct {
+		align font.Align
+		x     int
+	}{
+		{font.AlignLeft, 10},
+		{font.AlignCenter, 24},
+		{font.AlignRight, 39},
+		{font.AlignJustify, 10},
+	}
+
+	for _, test := range tests {
+		l := f.Layout("HI", font.LayoutOptions{Rect: rect(10, 5, 50, 20), Align: test.align})
+
+		want := []font.Run{run("HI", test.x, 5, 11)}
+		if !reflect.DeepEqual(l.Runs, want) {
+			t.Errorf("align %d: got %v, want %v", test.align, l.Runs, want)
+		}
+	}
+}
+
+func TestLayoutWrap(t *testing.T) {
+	l := testFont().Layout("HELLO WORLD FOO\nBAR", font.LayoutOptions{
+		Rect:        rect(0, 0, 40, 40),
+		Wrap:        true,
+		LineSpacing: 1,
+	})
+
+	want := []font.Run{
+		run("HELLO", 0, 0, 29),
+		run("WORLD", 0, 8, 29),
+		run("FOO", 0, 16, 17),
+		run("BAR", 0, 24, 17),
+	}
+
+	if !reflect.DeepEqual(l.Runs, want) || l.Lines != 4 || l.Truncated {
+		t.Fatalf("got %v (%d lines, truncated %v)", l.Runs, l.Lines, l.Truncated)
+	}
+}
+
+func TestLayoutJustify(t *testing.T) {
+	l := testFont().Layout("AB CD EF GH", font.LayoutOptions{
+		Rect:  rect(0, 0, 40, 20),
+		Align: font.AlignJustify,
+		Wrap:  true,
+	})
+
+	want := []font.Run{
+		run("AB", 0, 0, 11),
+		run("CD", 29, 0, 11),
+		run("EF GH", 0, 7, 26),
+	}
+
+	if !reflect.DeepEqual(l.Runs, want) {
+		t.Fatalf("got %v, want %v", l.Runs, want)
+	}
+}
+
+func TestLayoutEllipsis(t *testing.T) {
+	f := testFont()
+
+	l := f.Layout("HELLO WORLD", font.LayoutOptions{Rect: rect(0, 0, 40, 10), Wrap: true, Ellipsis: "..."})
+	if want := []font.Run{run("HELLO...", 0, 0, 35)}; !reflect.DeepEqual(l.Runs, want) || !l.Truncated {
+		t.Fatalf("got %v, want %v", l.Runs, want)
+	}
+
+	l = f.Layout("HELLO WORLD", font.LayoutOptions{Rect: rect(0, 0, 40, 10), Ellipsis: ".."})
+	if want := []font.Run{run("HELLO..", 0, 0, 33)}; !reflect.DeepEqual(l.Runs, want) || !l.Truncated {
+		t.Fatalf("got %v, want %v", l.Runs, want)
+	}
+}
+
+func TestLayoutBreaksLongWords(t *testing.T) {
+	l := testFont().Layout("ÄÖÜÄÖÜÄ", font.LayoutOptions{Rect: rect(0, 0, 17, 30), Wrap: true})
+
+	want := []font.Run{
+		run("ÄÖÜ", 0, 0, 17),
+		run("ÄÖÜ", 0, 7, 17),
+		run("Ä", 0, 14, 5),
+	}
+
+	if !reflect.DeepEqual(l.Runs, want) {
+		t.Fatalf("got %v, want %v", l.Runs, want)
+	}
+}
+
+func TestLayoutDraw(t *testing.T) {
+	rt := scripttest.New(t)
+
+	tex := texture.Create(texture.CreationOptions{Width: 40, Height: 20})
+	amber := texture.Color{R: 255, G: 191, A: 255}
+
+	l := testFont().Layout("AB CD", font.LayoutOptions{Rect: rect(0, 0, 40, 20), Align: font.AlignRight, LetterSpacing: 1})
+	l.Draw(tex, texture.DrawTextOptions{FullColor: &amber})
+	tex.Flush()
+
+	actions := rt.Texture(tex).Actions
+	if len(actions) != 1 {
+		t.Fatalf("got %d actions, want 1", len(actions))
+	}
+
+	text := actions[0].(map[string]any)["DrawText"].(map[string]any)
+	if text["text"] != "AB CD" || !reflect.DeepEqual(text["top_left"], []any{int64(10), int64(0)}) || text["letter_spacing"] != int64(1) {
+		t.Fatalf("got %v", text)
+	}
+}
@@ -0,0 +1,115 @@
+package font
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ErrMissingGlyph is returned when a font has no letter for a character and
+// no fallback letter.
+var ErrMissingGlyph = errors.New("missing glyph")
+
+// Measure returns the width of text in pixels without calling the host.
+// Letters are separated by the font's HorizontalDistance plus
+// letterSpacing. A character followed by combining marks, such as a
+// decomposed umlaut, counts as one letter. Characters the font does not
+// have use the Fallback letter; without one they are skipped and an error
+// wrapping ErrMissingGlyph is returned together with the width of the rest.
+func (f *BitmapFont) Measure(text string, letterSpacing int) (int, error) {
+	width, count := 0, 0
+	var missing []string
+
+	f.letters(text, func(_ string, letter FontLetter, ok bool, char string) {
+		if !ok {
+			missing = append(missing, char)
+			return
+		}
+
+		width += int(letter.Width)
+		count++
+	})
+
+	if count > 1 {
+		width += (count - 1) * (int(f.HorizontalDistance) + letterSpacing)
+	}
+
+	if len(missing) > 0 {
+		return width, fmt.Errorf("%w for %q in font %v", ErrMissingGlyph, strings.Join(missing, ""), f.ContentId)
+	}
+
+	return width, nil
+}
+
+// Normalize returns text as the font draws it: characters the font does not
+// have are looked up in Unicode normalization form C, so decomposed letters
+// use their precomposed glyph, and characters still missing are replaced by
+// the Fallback letter or dropped. Every character of the result is a letter
+// of the font.
+func (f *BitmapFont) Normalize(text string) string {
+	var b strings.Builder
+	f.letters(text, func(key string, _ FontLetter, ok bool, _ string) {
+		if ok {
+			b.WriteString(key)
+		}
+	})
+
+	return b.String()
+}
+
+// letters calls fn for every character of text with the key of the letter
+// drawing it.
+func (f *BitmapFont) letters(text string, fn func(key string, letter FontLetter, ok bool, char string)) {
+	for text != "" {
+		n := clusterLen(text)
+		char := text[:n]
+		text = text[n:]
+
+		key, ok := f.lookup(char)
+		fn(key, f.Letters[key], ok, char)
+	}
+}
+
+func (f *BitmapFont) lookup(char string) (string, bool) {
+	if _, ok := f.Letters[char]; ok {
+		return char, true
+	}
+
+	if composed := norm.NFC.String(char); composed != char {
+		if _, ok := f.Letters[composed]; ok {
+			return composed, true
+		}
+	}
+
+	if _, ok := f.Letters[f.Fallback]; ok && f.Fallback != "" {
+		return f.Fallback, true
+	}
+
+	return "", false
+}
+
+// clusterLen returns the byte length of the first character of text: a
+// rune together with the runes it composes with, such as combining marks
+// or Hangul jamo.
+func clusterLen(text string) int {
+	return norm.NFC.NextBoundaryInString(text, true)
+}
+
+// lastClusterLen returns the byte length of the last character of text.
+func lastClusterLen(text string) int {
+	i := norm.NFC.LastBoundary([]byte(text))
+	if i < 0 {
+		return len(text)
+	}
+
+	// Invalid UTF-8 at the end counts as a boundary of its own.
+	if i == len(text) {
+		_, size := utf8.DecodeLastRuneInString(text)
+		return size
+	}
+
+	return len(text) - i
+}
@@ -0,0 +1,112 @@
+package font_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/oriolus-software/script-go/assets"
+	"github.com/oriolus-software/script-go/font"
+	"github.com/oriolus-software/script-go/scripttest"
+)
+
+func TestMeasure(t *testing.T) {
+	f := testFont()
+
+	tests := []struct {
+		text    string
+		spacing int
+		width   int
+	}{
+		{"", 0, 0},
+		{"A", 3, 5},
+		{"AB", 0, 11},
+		{"AB", 2, 13},
+		{"A B", 0, 14},
+		{"ÄÖÜ", 0, 17},
+		// Decomposed umlauts count as one letter.
+		{"A\u0308O\u0308", 0, 11},
+	}
+
+	for _, test := range tests {
+		width, err := f.Measure(test.text, test.spacing)
+		if err != nil || width != test.width {
+			t.Errorf("Measure(%q, %d) = %d, %v, want %d", test.text, test.spacing, width, err, test.width)
+		}
+	}
+}
+
+func TestMeasureMissingGlyph(t *testing.T) {
+	f := testFont()
+
+	width, err := f.Measure("A#B", 0)
+	if !errors.Is(err, font.ErrMissingGlyph) {
+		t.Fatalf("got error %v, want ErrMissingGlyph", err)
+	}
+
+	if width != 11 {
+		t.Fatalf("width without the missing glyph = %d, want 11", width)
+	}
+
+	f.Fallback = "."
+	width, err = f.Measure("A#B", 0)
+	if err != nil || width != 13 {
+		t.Fatalf("with fallback got %d, %v, want 13", width, err)
+	}
+
+	if got := f.Normalize("A#Ü"); got != "A.Ü" {
+		t.Fatalf("Normalize = %q", got)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	f := testFont()
+	for _, r := range "ÅĚŐȘΩ가" {
+		f.Letters[string(r)] = font.FontLetter{Character: string(r), Width: 5}
+	}
+
+	// The expected strings are written out by hand, not derived from the
+	// simulated host, which measures precomposed text only.
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"A\u0308", "Ä"},
+		{"E\u030c", "Ě"},
+		{"O\u030b", "Ő"},
+		{"S\u0326", "Ș"},
+		// Singletons compose to their canonical letter.
+		{"\u212b", "Å"},
+		{"\u2126", "Ω"},
+		// Hangul jamo compose to a syllable.
+		{"\u1100\u1161", "가"},
+		// Marks the font cannot compose are dropped with the letter.
+		{"A\u0301B", "B"},
+		{"A\xff", "A"},
+	}
+
+	for _, test := range tests {
+		if got := f.Normalize(test.text); got != test.want {
+			t.Errorf("Normalize(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
+
+func TestTextLen(t *testing.T) {
+	rt := scripttest.New(t)
+
+	id := assets.ContentId{UserId: 7, SubId: 3}
+	rt.AddFont(id, testFont().BitmapFontProperties)
+
+	f, ok := font.LoadBitmapFontProperties(id)
+	if !ok {
+		t.Fatal("font not loaded")
+	}
+
+	if width, err := f.TextLen("AB", 2); err != nil || width != 13 {
+		t.Fatalf("TextLen = %d, %v, want 13", width, err)
+	}
+
+	if _, err := f.TextLen("#", 0); err == nil {
+		t.Fatal("TextLen of a missing glyph did not fail")
+	}
+}
@@ -0,0 +1,122 @@
+// Package gizmo draws debug shapes in world space.
+//
+// Every gizmo is sent to the draw import of the gizmo module as a map with
+// the keys "kind", "color" and "lifetime". The kind is an externally tagged
+// value, e.g. {"WireSphere": {"center": [x, y, z], "radius": r}}, the same
+// layout the texture actions use. There is no public reference of the host's
+// gizmo format yet, so the names and keys are the ones the simulated host in
+// internal/host records and gizmo_test.go pins down; they have not been
+// checked against the game.
+package gizmo
+
+import (
+	"github.com/oriolus-software/script-go/internal/ffi"
+	"github.com/oriolus-software/script-go/lmath"
+)
+
+// Color is a linear RGBA color with components in the range 0..1.
+type Color struct {
+	R float32 `msgpack:"r"`
+	G float32 `msgpack:"g"`
+	B float32 `msgpack:"b"`
+	A float32 `msgpack:"a"`
+}
+
+var (
+	White  = Color{R: 1, G: 1, B: 1, A: 1}
+	Red    = Color{R: 1, A: 1}
+	Green  = Color{G: 1, A: 1}
+	Blue   = Color{B: 1, A: 1}
+	Yellow = Color{R: 1, G: 1, A: 1}
+)
+
+type Options struct {
+	Color Color
+	// Lifetime is the number of seconds the gizmo stays visible.
+	// Zero draws the gizmo for the current frame only.
+	Lifetime float32
+}
+
+// Line draws a line between two points in world space.
+func Line(start, end lmath.Vec3, opts Options) {
+	type line struct {
+		Start lmath.Vec3 `msgpack:"start"`
+		End   lmath.Vec3 `msgpack:"end"`
+	}
+
+	draw(struct {
+		Line line
+	}{
+		Line: line{Start: start, End: end},
+	}, opts)
+}
+
+// Arrow draws an arrow from start pointing towards end in world space.
+func Arrow(start, end lmath.Vec3, opts Options) {
+	type arrow struct {
+		Start lmath.Vec3 `msgpack:"start"`
+		End   lmath.Vec3 `msgpack:"end"`
+	}
+
+	draw(struct {
+		Arrow arrow
+	}{
+		Arrow: arrow{Start: start, End: end},
+	}, opts)
+}
+
+// Sphere draws a wireframe sphere in world space.
+func Sphere(center lmath.Vec3, radius float32, opts Options) {
+	type sphere struct {
+		Center lmath.Vec3 `msgpack:"center"`
+		Radius float32    `msgpack:"radius"`
+	}
+
+	draw(struct {
+		WireSphere sphere
+	}{
+		WireSphere: sphere{Center: center, Radius: radius},
+	}, opts)
+}
+
+// Box draws a wireframe axis-aligned box in world space.
+func Box(center, halfExtents lmath.Vec3, opts Options) {
+	type box struct {
+		Center      lmath.Vec3 `msgpack:"center"`
+		HalfExtents lmath.Vec3 `msgpack:"half_extents"`
+	}
+
+	draw(struct {
+		WireCube box
+	}{
+		WireCube: box{Center: center, HalfExtents: halfExtents},
+	}, opts)
+}
+
+// Text draws a text label at a position in world space.
+func Text(position lmath.Vec3, text string, opts Options) {
+	type label struct {
+		Position lmath.Vec3 `msgpack:"position"`
+		Text     string     `msgpack:"text"`
+	}
+
+	draw(struct {
+		Text label
+	}{
+		Text: label{Position: position, Text: text},
+	}, opts)
+}
+
+func draw(kind any, opts Options) {
+	type gizmo struct {
+		Kind     any     `msgpack:"kind"`
+		Color    Color   `msgpack:"color"`
+		Lifetime float32 `msgpack:"lifetime"`
+	}
+
+	drawGizmo(ffi.Serialize(gizmo{
+		Kind:     kind,
+		Color:    opts.Color,
+		Lifetime: opts.Lifetime,
+	}).ToPacked())
+}
@@ -0,0 +1,62 @@
+package gizmo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oriolus-software/script-go/gizmo"
+	"github.com/oriolus-software/script-go/lmath"
+	"github.com/oriolus-software/script-go/scripttest"
+)
+
+func TestDraw(t *testing.T) {
+	rt := scripttest.New(t)
+
+	a := lmath.Vec3{X: 1, Y: 2, Z: 3}
+	b := lmath.Vec3{X: 4, Y: 5, Z: 6}
+	opts := gizmo.Options{Color: gizmo.Red, Lifetime: 2}
+
+	gizmo.Line(a, b, opts)
+	gizmo.Arrow(a, b, opts)
+	gizmo.Sphere(a, 0.5, opts)
+	gizmo.Box(a, b, opts)
+	gizmo.Text(a, "door 1", gizmo.Options{Color: gizmo.White})
+
+	vec := func(v lmath.Vec3) []any {
+		return []any{v.X, v.Y, v.Z}
+	}
+	red := map[string]any{"r": float32(1), "g": float32(0), "b": float32(0), "a": float32(1)}
+	white := map[string]any{"r": float32(1), "g": float32(1), "b": float32(1), "a": float32(1)}
+
+	want := []any{
+		map[string]any{
+			"kind":     map[string]any{"Line": map[string]any{"start": vec(a), "end": vec(b)}},
+			"color":    red,
+			"lifetime": float32(2),
+		},
+		map[string]any{
+			"kind":     map[string]any{"Arrow": map[string]any{"start": vec(a), "end": vec(b)}},
+			"color":    red,
+			"lifetime": float32(2),
+		},
+		map[string]any{
+			"kind":     map[string]any{"WireSphere": map[string]any{"center": vec(a), "radius": float32(0.5)}},
+			"color":    red,
+			"lifetime": float32(2),
+		},
+		map[string]any{
+			"kind":     map[string]any{"WireCube": map[string]any{"center": vec(a), "half_extents": vec(b)}},
+			"color":    red,
+			"lifetime": float32(2),
+		},
+		map[string]any{
+			"kind":     map[string]any{"Text": map[string]any{"position": vec(a), "text": "door 1"}},
+			"color":    white,
+			"lifetime": float32(0),
+		},
+	}
+
+	if got := rt.Gizmos(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected gizmos\ngot  %#v\nwant %#v", got, want)
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !wasm
+
+package gizmo
+
+import (
+	"github.com/oriolus-software/script-go/internal/ffi"
+	"github.com/oriolus-software/script-go/internal/host"
+)
+
+func drawGizmo(gizmo uint64) {
+	host.Current.DrawGizmo(ffi.Deserialize[any](gizmo))
+}
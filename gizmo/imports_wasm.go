@@ -0,0 +1,7 @@
+//go:build wasm
+
+package gizmo
+
+//go:wasm-module gizmo
+//export draw
+func drawGizmo(gizmo uint64)
@@ -2,6 +2,9 @@ module github.com/oriolus-software/script-go
 
 go 1.24.6
 
-require github.com/vmihailenco/msgpack/v5 v5.4.1
+require (
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+	golang.org/x/text v0.28.0
+)
 
 require github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
@@ -1,6 +1,7 @@
 package input
 
 import (
+	"github.com/oriolus-software/script-go/internal/exports"
 	"github.com/oriolus-software/script-go/internal/ffi"
 	"github.com/oriolus-software/script-go/lmath"
 )
@@ -19,6 +20,8 @@ type registerAction struct {
 
 var actions = make(map[string]registerAction, 0)
 
+// RegisterAction adds an action that is registered with the host by the
+// register_actions export, before Init runs.
 func RegisterAction(id, defaultKey string) {
 	actions[id] = registerAction{
 		Id:         id,
@@ -48,25 +51,30 @@ func (a ActionState) IsNone() bool {
 	return a.Kind == KindNone
 }
 
-//go:wasm-module action
-//export state
-func getState(actionId uint64) uint64
-
 func State(actionId string) ActionState {
 	var state ActionState
 	ffi.DeserializeInto(getState(ffi.Serialize(actionId).ToPacked()), &state)
 	return state
 }
 
+// register_actions registers the actions, unless the script package does so
+// itself.
+//
 //export register_actions
 func register_actions() {
+	if exports.RegisterActions != nil {
+		exports.RegisterActions()
+		return
+	}
+
+	FlushActions()
+}
+
+// FlushActions registers the actions added by RegisterAction with the host.
+func FlushActions() {
 	for _, action := range actions {
 		register_action(ffi.Serialize(action).ToPacked())
 	}
 
 	actions = make(map[string]registerAction, 0)
 }
-
-//go:wasm-module action
-//export register
-func register_action(action uint64)
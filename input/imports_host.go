@@ -0,0 +1,27 @@
+//go:build !wasm
+
+package input
+
+import (
+	"github.com/oriolus-software/script-go/internal/ffi"
+	"github.com/oriolus-software/script-go/internal/host"
+)
+
+func getState(actionId uint64) uint64 {
+	return ffi.Serialize(host.Current.ActionState(ffi.Deserialize[string](actionId))).ToPacked()
+}
+
+func register_action(action uint64) {
+	host.Current.RegisterAction(ffi.Deserialize[host.RegisteredAction](action))
+}
+
+func mouse_delta() uint64 {
+	return ffi.Serialize(host.Current.Input.MouseDelta).ToPacked()
+}
+
+func init() {
+	host.Export("register_actions", register_actions)
+	host.OnReset(func() {
+		actions = make(map[string]registerAction, 0)
+	})
+}
@@ -0,0 +1,15 @@
+//go:build wasm
+
+package input
+
+//go:wasm-module action
+//export state
+func getState(actionId uint64) uint64
+
+//go:wasm-module action
+//export register
+func register_action(action uint64)
+
+//go:wasm-module input
+//export mouse_delta
+func mouse_delta() uint64
@@ -0,0 +1,17 @@
+// Package exports lets the script package take over the exports that older
+// SDK packages own.
+//
+// The message package exports late_tick and the input package
+// register_actions, so modules that do not use the script package keep
+// dispatching messages and registering actions. When the script package is
+// linked, these exports call into it instead.
+package exports
+
+var (
+	// LateTick replaces message.Dispatch as the body of late_tick. The
+	// script package sets it and dispatches messages itself.
+	LateTick func()
+	// RegisterActions replaces input.FlushActions as the body of
+	// register_actions.
+	RegisterActions func()
+)
@@ -0,0 +1,47 @@
+//go:build !wasm
+
+package ffi
+
+import (
+	"encoding/binary"
+)
+
+// Native pointers do not fit into the 32-bit slot of the packed format, so
+// outside of WASM objects live in a ring of slots and the slot number takes
+// the place of the pointer. Slot 0 is never used so that a packed value of
+// 0 keeps meaning "nothing".
+const slotCount = 4096
+
+var (
+	slots    [slotCount][]byte
+	nextSlot uint32
+)
+
+type FfiObject struct {
+	slot uint32
+	len  uint32
+}
+
+func (o FfiObject) ToPacked() uint64 {
+	var packed [8]byte
+	binary.BigEndian.PutUint32(packed[:4], o.slot)
+	binary.BigEndian.PutUint32(packed[4:], o.len)
+	return binary.BigEndian.Uint64(packed[:])
+}
+
+func store(data []byte) FfiObject {
+	nextSlot = nextSlot%(slotCount-1) + 1
+	slots[nextSlot] = data
+
+	return FfiObject{slot: nextSlot, len: uint32(len(data))}
+}
+
+func fromPacked(packed uint64) []byte {
+	var packedBytes [8]byte
+	binary.BigEndian.PutUint64(packedBytes[:], packed)
+
+	slot := binary.BigEndian.Uint32(packedBytes[:4])
+	len := binary.BigEndian.Uint32(packedBytes[4:])
+
+	return slots[slot][:len]
+}
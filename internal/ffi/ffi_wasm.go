@@ -0,0 +1,43 @@
+//go:build wasm
+
+package ffi
+
+import (
+	"encoding/binary"
+	"unsafe"
+
+	"github.com/oriolus-software/script-go/internal/alloc"
+)
+
+type FfiObject struct {
+	ptr unsafe.Pointer
+	len uint32
+}
+
+func (o FfiObject) ToPacked() uint64 {
+	// Match Rust format: [ptr: 4 bytes][len: 4 bytes] in big-endian
+	var packed [8]byte
+	binary.BigEndian.PutUint32(packed[:4], uint32(uintptr(o.ptr)))
+	binary.BigEndian.PutUint32(packed[4:], o.len)
+	return binary.BigEndian.Uint64(packed[:])
+}
+
+func store(data []byte) FfiObject {
+	memPtr := alloc.Allocate(len(data))
+	buf := unsafe.Slice((*byte)(memPtr), len(data))
+	copy(buf, data)
+
+	return FfiObject{ptr: memPtr, len: uint32(len(data))}
+}
+
+func fromPacked(packed uint64) []byte {
+	// Match Rust format: [ptr: 4 bytes][len: 4 bytes] in big-endian
+	var packedBytes [8]byte
+	binary.BigEndian.PutUint64(packedBytes[:], packed)
+
+	ptr := binary.BigEndian.Uint32(packedBytes[:4])
+	len := binary.BigEndian.Uint32(packedBytes[4:])
+
+	// Safe: ptr is a valid WASM memory address from the allocator
+	return unsafe.Slice((*byte)(unsafe.Pointer(uintptr(ptr))), int(len))
+}
@@ -1,37 +1,33 @@
 package ffi
 
 import (
-	"encoding/binary"
-	"unsafe"
-
-	"github.com/oriolus-software/script-go/internal/alloc"
 	"github.com/oriolus-software/script-go/internal/msgpack"
 )
 
-type FfiObject struct {
-	ptr unsafe.Pointer
-	len uint32
-}
+func Serialize(val any) FfiObject {
+	data, err := msgpack.Marshal(val)
+	if err != nil {
+		panic(err)
+	}
 
-func (o FfiObject) ToPacked() uint64 {
-	// Match Rust format: [ptr: 4 bytes][len: 4 bytes] in big-endian
-	var packed [8]byte
-	binary.BigEndian.PutUint32(packed[:4], uint32(uintptr(o.ptr)))
-	binary.BigEndian.PutUint32(packed[4:], o.len)
-	return binary.BigEndian.Uint64(packed[:])
+	return store(data)
 }
 
-func Serialize(val any) FfiObject {
+// Prepared is a value serialized once up front, for values that are passed
+// to the host over and over again.
+type Prepared []byte
+
+func Prepare(val any) Prepared {
 	data, err := msgpack.Marshal(val)
 	if err != nil {
 		panic(err)
 	}
 
-	memPtr := alloc.Allocate(len(data))
-	buf := unsafe.Slice((*byte)(memPtr), len(data))
-	copy(buf, data)
+	return Prepared(data)
+}
 
-	return FfiObject{ptr: memPtr, len: uint32(len(data))}
+func (p Prepared) ToPacked() uint64 {
+	return store(p).ToPacked()
 }
 
 func Deserialize[T any](packed uint64) T {
@@ -44,6 +40,13 @@ func Deserialize[T any](packed uint64) T {
 	return result
 }
 
+// Bytes returns a copy of the data of a packed object. Unlike the data
+// behind a packed object it stays valid when later calls reuse host memory.
+func Bytes(packed uint64) []byte {
+	data := fromPacked(packed)
+	return append(make([]byte, 0, len(data)), data...)
+}
+
 func DeserializeInto[T any](packed uint64, v *T) {
 	data := fromPacked(packed)
 	err := msgpack.Unmarshal(data, v)
@@ -51,15 +54,3 @@ func DeserializeInto[T any](packed uint64, v *T) {
 		panic(err)
 	}
 }
-
-func fromPacked(packed uint64) []byte {
-	// Match Rust format: [ptr: 4 bytes][len: 4 bytes] in big-endian
-	var packedBytes [8]byte
-	binary.BigEndian.PutUint64(packedBytes[:], packed)
-
-	ptr := binary.BigEndian.Uint32(packedBytes[:4])
-	len := binary.BigEndian.Uint32(packedBytes[4:])
-
-	// Safe: ptr is a valid WASM memory address from the allocator
-	return unsafe.Slice((*byte)(unsafe.Pointer(uintptr(ptr))), int(len))
-}
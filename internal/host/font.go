@@ -0,0 +1,45 @@
+package host
+
+type BitmapFont struct {
+	HorizontalDistance int32                 `msgpack:"horizontal_distance"`
+	VerticalSize       int32                 `msgpack:"vertical_size"`
+	Letters            map[string]FontLetter `msgpack:"letters"`
+}
+
+type FontLetter struct {
+	Character string `msgpack:"character"`
+	Start     uint32 `msgpack:"start"`
+	Width     uint32 `msgpack:"width"`
+}
+
+func (h *Host) BitmapFont(id ContentId) (BitmapFont, bool) {
+	font, ok := h.Fonts[id]
+	return font, ok
+}
+
+// TextLen returns the width of text in pixels, or -1 if the font is not
+// known or contains a character the font does not have.
+func (h *Host) TextLen(id ContentId, text string, letterSpacing int) int {
+	font, ok := h.Fonts[id]
+	if !ok {
+		return -1
+	}
+
+	width := 0
+	count := 0
+	for _, r := range text {
+		letter, ok := font.Letters[string(r)]
+		if !ok {
+			return -1
+		}
+
+		width += int(letter.Width)
+		count++
+	}
+
+	if count > 1 {
+		width += (count - 1) * (int(font.HorizontalDistance) + letterSpacing)
+	}
+
+	return width
+}
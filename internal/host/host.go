@@ -0,0 +1,152 @@
+// Package host is an in-memory stand-in for the game host. It backs the
+// native (non-WASM) build of every import module so scripts can be run and
+// inspected with `go test`.
+package host
+
+import (
+	"math/rand/v2"
+)
+
+type Host struct {
+	Vars     map[string]any
+	Time     Time
+	Env      Env
+	Rand     *rand.Rand
+	Logs     []LogEntry
+	Preloads []ContentId
+	Input    Input
+	Messages Messages
+	Textures map[uint32]*Canvas
+	Fonts    map[ContentId]BitmapFont
+	Vehicle  Vehicle
+	Gizmos   []any
+
+	nextTexture uint32
+}
+
+type ContentId struct {
+	UserId int `msgpack:"user_id"`
+	SubId  int `msgpack:"sub_id"`
+}
+
+type Time struct {
+	// Delta is the duration of the current tick in seconds.
+	Delta float64
+	Ticks uint64
+	// GameTime in unix microseconds.
+	GameTime int64
+}
+
+type Env struct {
+	IsRC                        bool
+	ModuleSlotIndex             int // -1 if not set
+	ModuleSlotCockpitIndex      int // -1 if not set
+	ModuleSlotIndexInClassGroup int // -1 if not set
+}
+
+type LogEntry struct {
+	Level   int
+	Message string
+}
+
+// Current is the host every native import module talks to.
+var Current = New()
+
+var exports = make(map[string]func())
+
+var resets []func()
+
+func New() *Host {
+	return &Host{
+		Vars: make(map[string]any),
+		Time: Time{Delta: 1.0 / 60.0},
+		Env: Env{
+			ModuleSlotIndex:             -1,
+			ModuleSlotCockpitIndex:      -1,
+			ModuleSlotIndexInClassGroup: -1,
+		},
+		Rand:     rand.New(rand.NewPCG(0, 0)),
+		Input:    Input{States: make(map[string]ActionState)},
+		Textures: make(map[uint32]*Canvas),
+		Fonts:    make(map[ContentId]BitmapFont),
+		Vehicle: Vehicle{
+			Bogies:      []Bogie{{Axles: make([]Axle, 2)}, {Axles: make([]Axle, 2)}},
+			Pantographs: []Pantograph{{}},
+		},
+	}
+}
+
+// Reset replaces the current host state with a fresh one and resets the
+// package state of the SDK registered with OnReset. Registered exports are
+// kept since they belong to the script, not to the host.
+func Reset() {
+	Current = New()
+
+	for _, reset := range resets {
+		reset()
+	}
+}
+
+// OnReset registers fn to be called by Reset. SDK packages use it to drop
+// state that refers to the previous host, like handlers and variable handles.
+func OnReset(fn func()) {
+	resets = append(resets, fn)
+}
+
+// Export registers fn as the script export called name, mirroring a
+// `//export name` function of the WASM build, and returns the function it
+// replaced. A nil fn removes the export.
+func Export(name string, fn func()) (previous func()) {
+	previous = exports[name]
+	if fn == nil {
+		delete(exports, name)
+	} else {
+		exports[name] = fn
+	}
+
+	return previous
+}
+
+// Call invokes the script export name if it is registered and reports
+// whether it was.
+func Call(name string) bool {
+	fn, ok := exports[name]
+	if !ok {
+		return false
+	}
+
+	fn()
+	return true
+}
+
+func (h *Host) Write(level int, message string) {
+	h.Logs = append(h.Logs, LogEntry{Level: level, Message: message})
+}
+
+func (h *Host) Preload(id ContentId) {
+	h.Preloads = append(h.Preloads, id)
+}
+
+func (h *Host) U64(min, max uint64) uint64 {
+	if max <= min {
+		return min
+	}
+
+	return min + h.Rand.Uint64N(max-min)
+}
+
+func (h *Host) F64() float64 {
+	return h.Rand.Float64()
+}
+
+func (h *Host) RandomSeed() {
+	h.Rand = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+}
+
+func (h *Host) Seed(seed uint64) {
+	h.Rand = rand.New(rand.NewPCG(seed, 0))
+}
+
+func (h *Host) DrawGizmo(gizmo any) {
+	h.Gizmos = append(h.Gizmos, gizmo)
+}
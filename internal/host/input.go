@@ -0,0 +1,26 @@
+package host
+
+type Input struct {
+	Registered []RegisteredAction
+	States     map[string]ActionState
+	MouseDelta [2]float32
+}
+
+type RegisteredAction struct {
+	Id         string `msgpack:"id"`
+	DefaultKey string `msgpack:"default_key"`
+}
+
+type ActionState struct {
+	Kind         int         `msgpack:"kind"`
+	CockpitIndex *int        `msgpack:"cockpit_index"`
+	UV           *[2]float32 `msgpack:"uv"`
+}
+
+func (h *Host) RegisterAction(action RegisteredAction) {
+	h.Input.Registered = append(h.Input.Registered, action)
+}
+
+func (h *Host) ActionState(id string) ActionState {
+	return h.Input.States[id]
+}
@@ -0,0 +1,87 @@
+package host
+
+type Messages struct {
+	// Inbox is drained by the next call to Take. Messages a script sends to
+	// itself are queued here as well.
+	Inbox []IncomingMessage
+	Sent  []SentMessage
+}
+
+type MessageMeta struct {
+	Namespace  string `msgpack:"namespace"`
+	Identifier string `msgpack:"identifier"`
+	Bus        string `msgpack:"bus"`
+}
+
+type MessageSource struct {
+	Coupling               string `msgpack:"coupling"`
+	ModuleSlotIndex        int    `msgpack:"module_slot_index"`
+	ModuleSlotCockpitIndex int    `msgpack:"module_slot_cockpit_index"`
+}
+
+type IncomingMessage struct {
+	Meta   MessageMeta   `msgpack:"meta"`
+	Source MessageSource `msgpack:"source"`
+	Value  any           `msgpack:"value"`
+}
+
+type SentMessage struct {
+	Meta MessageMeta
+	// Targets holds the decoded targets, e.g. "Myself" or
+	// map[string]any{"ChildByIndex": int64(0)}.
+	Targets []any
+	Value   any
+}
+
+func (h *Host) Take() []IncomingMessage {
+	messages := h.Messages.Inbox
+	h.Messages.Inbox = nil
+	if messages == nil {
+		messages = []IncomingMessage{}
+	}
+
+	return messages
+}
+
+func (h *Host) Send(targets []any, message map[string]any) {
+	sent := SentMessage{Targets: targets, Value: message["value"]}
+
+	if meta, ok := message["meta"].(map[string]any); ok {
+		sent.Meta.Namespace, _ = meta["namespace"].(string)
+		sent.Meta.Identifier, _ = meta["identifier"].(string)
+		sent.Meta.Bus, _ = meta["bus"].(string)
+	}
+
+	h.Messages.Sent = append(h.Messages.Sent, sent)
+
+	if loopsBack(targets) {
+		h.Messages.Inbox = append(h.Messages.Inbox, IncomingMessage{
+			Meta: sent.Meta,
+			Source: MessageSource{
+				ModuleSlotIndex:        -1,
+				ModuleSlotCockpitIndex: -1,
+			},
+			Value: sent.Value,
+		})
+	}
+}
+
+// loopsBack reports whether a message sent to targets is delivered to the
+// sending script itself.
+func loopsBack(targets []any) bool {
+	for _, target := range targets {
+		switch target := target.(type) {
+		case string:
+			if target == "Myself" {
+				return true
+			}
+		case map[string]any:
+			broadcast, ok := target["Broadcast"].(map[string]any)
+			if ok && broadcast["include_self"] == true {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,237 @@
+package host
+
+type Color struct {
+	R, G, B, A uint8
+}
+
+type Canvas struct {
+	Width   int
+	Height  int
+	MipMaps bool
+	Pixels  []Color
+	// Actions holds every flushed action in the order it was applied,
+	// decoded into generic msgpack values.
+	Actions []any
+	// AppliedTo and Exposed record the names passed to apply_to and expose.
+	AppliedTo []string
+	Exposed   []string
+	Disposed  bool
+
+	pending []any
+}
+
+type TextureOptions struct {
+	Width   int  `msgpack:"width"`
+	Height  int  `msgpack:"height"`
+	MipMaps bool `msgpack:"mipmaps"`
+}
+
+func (h *Host) CreateTexture(opts TextureOptions) uint32 {
+	h.nextTexture++
+	h.Textures[h.nextTexture] = &Canvas{
+		Width:   opts.Width,
+		Height:  opts.Height,
+		MipMaps: opts.MipMaps,
+		Pixels:  make([]Color, opts.Width*opts.Height),
+	}
+
+	return h.nextTexture
+}
+
+// Texture returns the canvas for a texture handle. Unknown handles get a
+// detached empty canvas so that misuse does not crash the script.
+func (h *Host) Texture(handle uint32) *Canvas {
+	if c, ok := h.Textures[handle]; ok {
+		return c
+	}
+
+	return &Canvas{}
+}
+
+func (h *Host) FlushActions(handle uint32) {
+	c := h.Texture(handle)
+	for _, action := range c.pending {
+		h.apply(c, action)
+	}
+
+	c.Actions = append(c.Actions, c.pending...)
+	c.pending = nil
+}
+
+func (c *Canvas) AddAction(action any) {
+	c.pending = append(c.pending, action)
+}
+
+func (c *Canvas) Pixel(x, y int) Color {
+	if x < 0 || y < 0 || x >= c.Width || y >= c.Height {
+		return Color{}
+	}
+
+	return c.Pixels[y*c.Width+x]
+}
+
+// Region returns the RGBA bytes of an area row by row. Pixels outside of
+// the canvas are transparent black.
+func (c *Canvas) Region(x, y, width, height int) []byte {
+	data := make([]byte, 0, width*height*4)
+	for py := y; py < y+height; py++ {
+		for px := x; px < x+width; px++ {
+			color := c.Pixel(px, py)
+			data = append(data, color.R, color.G, color.B, color.A)
+		}
+	}
+
+	return data
+}
+
+func (c *Canvas) set(x, y int, color Color) {
+	if x < 0 || y < 0 || x >= c.Width || y >= c.Height {
+		return
+	}
+
+	c.Pixels[y*c.Width+x] = color
+}
+
+func (h *Host) apply(c *Canvas, action any) {
+	m, ok := action.(map[string]any)
+	if !ok {
+		return
+	}
+
+	for kind, args := range m {
+		switch kind {
+		case "Clear":
+			color := toColor(args)
+			for i := range c.Pixels {
+				c.Pixels[i] = color
+			}
+		case "DrawPixels":
+			pixels, _ := args.([]any)
+			for _, p := range pixels {
+				p, _ := p.(map[string]any)
+				x, y := toVec(p["pos"])
+				c.set(x, y, toColor(p["color"]))
+			}
+		case "DrawRect":
+			r, _ := args.(map[string]any)
+			sx, sy := toVec(r["start"])
+			ex, ey := toVec(r["end"])
+			color := toColor(r["color"])
+			for y := sy; y < ey; y++ {
+				for x := sx; x < ex; x++ {
+					c.set(x, y, color)
+				}
+			}
+		case "DrawScriptTexture":
+			d, _ := args.(map[string]any)
+			src := h.Texture(uint32(toInt(d["handle"])))
+			opts, _ := d["options"].(map[string]any)
+			h.blit(c, src, opts["SourceRect"], opts["TargetRect"], opts["alpha_mode"])
+		}
+	}
+}
+
+// blit copies the source rectangle of src into the target rectangle of dst
+// using nearest neighbour sampling.
+//
+// The alpha modes are simulated, they are not taken from the game: "Blend"
+// draws the source over the destination with straight alpha, a mask only
+// copies pixels with at least the mask's alpha and anything else, like
+// "Opaque", copies every pixel. They only let tests tell the modes apart,
+// the exact colors of blended pixels may differ in the game.
+func (h *Host) blit(dst, src *Canvas, sourceRect, targetRect, alphaMode any) {
+	ssx, ssy, sex, sey := toRect(sourceRect)
+	tsx, tsy, tex, tey := toRect(targetRect)
+
+	tw, th := tex-tsx, tey-tsy
+	sw, sh := sex-ssx, sey-ssy
+	if tw <= 0 || th <= 0 || sw <= 0 || sh <= 0 {
+		return
+	}
+
+	for y := 0; y < th; y++ {
+		for x := 0; x < tw; x++ {
+			color := src.Pixel(ssx+x*sw/tw, ssy+y*sh/th)
+
+			switch mode := alphaMode.(type) {
+			case string:
+				if mode == "Blend" {
+					color = blend(color, dst.Pixel(tsx+x, tsy+y))
+				}
+			case map[string]any:
+				if float64(color.A) < toFloat(mode["Mask"])*255 {
+					continue
+				}
+			}
+
+			dst.set(tsx+x, tsy+y, color)
+		}
+	}
+}
+
+// blend draws src over dst with straight alpha.
+func blend(src, dst Color) Color {
+	sa, da := int(src.A), int(dst.A)
+	a := sa + da*(255-sa)/255
+	if a == 0 {
+		return Color{}
+	}
+
+	mix := func(s, d uint8) uint8 {
+		return uint8((int(s)*sa + int(d)*da*(255-sa)/255) / a)
+	}
+
+	return Color{R: mix(src.R, dst.R), G: mix(src.G, dst.G), B: mix(src.B, dst.B), A: uint8(a)}
+}
+
+func toFloat(v any) float64 {
+	switch v := v.(type) {
+	case float32:
+		return float64(v)
+	case float64:
+		return v
+	}
+
+	return float64(toInt(v))
+}
+
+func toInt(v any) int {
+	switch v := v.(type) {
+	case int64:
+		return int(v)
+	case uint64:
+		return int(v)
+	case float32:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+
+	return 0
+}
+
+func toColor(v any) Color {
+	m, _ := v.(map[string]any)
+	return Color{
+		R: uint8(toInt(m["r"])),
+		G: uint8(toInt(m["g"])),
+		B: uint8(toInt(m["b"])),
+		A: uint8(toInt(m["a"])),
+	}
+}
+
+func toVec(v any) (int, int) {
+	a, _ := v.([]any)
+	if len(a) != 2 {
+		return 0, 0
+	}
+
+	return toInt(a[0]), toInt(a[1])
+}
+
+func toRect(v any) (int, int, int, int) {
+	m, _ := v.(map[string]any)
+	sx, sy := toVec(m["start"])
+	ex, ey := toVec(m["end"])
+	return sx, sy, ex, ey
+}
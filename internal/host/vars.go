@@ -0,0 +1,46 @@
+package host
+
+func (h *Host) GetI64(name string) int64 {
+	v, _ := h.Vars[name].(int64)
+	return v
+}
+
+func (h *Host) SetI64(name string, value int64) {
+	h.Vars[name] = value
+}
+
+func (h *Host) GetF64(name string) float64 {
+	v, _ := h.Vars[name].(float64)
+	return v
+}
+
+func (h *Host) SetF64(name string, value float64) {
+	h.Vars[name] = value
+}
+
+func (h *Host) GetBool(name string) bool {
+	v, _ := h.Vars[name].(bool)
+	return v
+}
+
+func (h *Host) SetBool(name string, value bool) {
+	h.Vars[name] = value
+}
+
+func (h *Host) GetString(name string) string {
+	v, _ := h.Vars[name].(string)
+	return v
+}
+
+func (h *Host) SetString(name string, value string) {
+	h.Vars[name] = value
+}
+
+func (h *Host) GetContentId(name string) ContentId {
+	v, _ := h.Vars[name].(ContentId)
+	return v
+}
+
+func (h *Host) SetContentId(name string, value ContentId) {
+	h.Vars[name] = value
+}
@@ -0,0 +1,93 @@
+package host
+
+const (
+	errorBogieNotFound      = 512
+	errorAxleNotFound       = 1024
+	errorPantographNotFound = 4096
+)
+
+type Vehicle struct {
+	Bogies       []Bogie
+	Pantographs  []Pantograph
+	Coupled      [2]bool
+	Velocity     float32
+	Acceleration float32
+}
+
+type Bogie struct {
+	Axles          []Axle
+	RailBrakeForce float32
+}
+
+type Axle struct {
+	TractionForce float32
+	BrakeForce    float32
+	RailQuality   uint32
+	SurfaceType   uint32
+	InverseRadius float32
+}
+
+type Pantograph struct {
+	Height  float64
+	Voltage float64
+}
+
+func (h *Host) BogieIsValid(bogie uint32) uint32 {
+	if int(bogie) >= len(h.Vehicle.Bogies) {
+		return errorBogieNotFound
+	}
+
+	return 0
+}
+
+func (h *Host) AxleIsValid(bogie, axle uint32) uint32 {
+	if ret := h.BogieIsValid(bogie); ret != 0 {
+		return ret
+	}
+
+	if int(axle) >= len(h.Vehicle.Bogies[bogie].Axles) {
+		return errorAxleNotFound
+	}
+
+	return 0
+}
+
+func (h *Host) PantographIsValid(pantograph uint32) uint32 {
+	if int(pantograph) >= len(h.Vehicle.Pantographs) {
+		return errorPantographNotFound
+	}
+
+	return 0
+}
+
+func (h *Host) Axle(bogie, axle uint32) *Axle {
+	if h.AxleIsValid(bogie, axle) != 0 {
+		return &Axle{}
+	}
+
+	return &h.Vehicle.Bogies[bogie].Axles[axle]
+}
+
+func (h *Host) Bogie(bogie uint32) *Bogie {
+	if h.BogieIsValid(bogie) != 0 {
+		return &Bogie{}
+	}
+
+	return &h.Vehicle.Bogies[bogie]
+}
+
+func (h *Host) Pantograph(pantograph uint32) *Pantograph {
+	if h.PantographIsValid(pantograph) != 0 {
+		return &Pantograph{}
+	}
+
+	return &h.Vehicle.Pantographs[pantograph]
+}
+
+func (h *Host) IsCoupled(end uint32) bool {
+	if int(end) >= len(h.Vehicle.Coupled) {
+		return false
+	}
+
+	return h.Vehicle.Coupled[end]
+}
@@ -257,6 +257,35 @@ func (r *Reader) ReadFloat64() (float64, error) {
 	return r.readFloat64()
 }
 
+// ReadFloat reads any numeric value as float64. Unlike ReadFloat64 it also
+// accepts float32 and integer encodings
+func (r *Reader) ReadFloat() (float64, error) {
+	b, err := r.peekByte()
+	if err != nil {
+		return 0, err
+	}
+
+	switch b {
+	case Float32:
+		v, err := r.ReadFloat32()
+		return float64(v), err
+	case Float64:
+		return r.ReadFloat64()
+	case Uint64:
+		v, err := r.ReadUint()
+		return float64(v), err
+	default:
+		v, err := r.ReadInt()
+		return float64(v), err
+	}
+}
+
+// IsNil reports whether the next value is nil without consuming it
+func (r *Reader) IsNil() bool {
+	b, err := r.peekByte()
+	return err == nil && b == Nil
+}
+
 // ReadString reads a string value
 func (r *Reader) ReadString() (string, error) {
 	data, err := r.readStringBytes()
@@ -497,6 +526,107 @@ func (r *Reader) ReadValue() (any, error) {
 	}
 }
 
+// Skip skips over the next value, including all elements of arrays and
+// maps, without decoding it
+func (r *Reader) Skip() error {
+	b, err := r.peekByte()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case b <= PositiveFixintMax || b >= NegativeFixintMin || b == Nil || b == True || b == False:
+		_, err = r.readBytes(1)
+	case b == Int8 || b == Uint8:
+		_, err = r.readBytes(2)
+	case b == Int16 || b == Uint16:
+		_, err = r.readBytes(3)
+	case b == Int32 || b == Uint32 || b == Float32:
+		_, err = r.readBytes(5)
+	case b == Int64 || b == Uint64 || b == Float64:
+		_, err = r.readBytes(9)
+	case (b&FixstrMask) == FixstrMask || b == Str8 || b == Str16 || b == Str32:
+		_, err = r.readStringBytes()
+	case b == Bin8 || b == Bin16 || b == Bin32:
+		err = r.skipBinary()
+	case (b >= FixarrayMask && b <= FixarrayEnd) || b == Array16 || b == Array32:
+		length, err := r.ReadArrayHeader()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < length; i++ {
+			if err := r.Skip(); err != nil {
+				return err
+			}
+		}
+	case (b >= FixmapMask && b <= FixmapEnd) || b == Map16 || b == Map32:
+		length, err := r.ReadMapHeader()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < 2*length; i++ {
+			if err := r.Skip(); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unknown type marker: 0x%02x", b)
+	}
+
+	return err
+}
+
+// ReadRaw returns the encoded bytes of the next value without decoding it.
+// The returned slice shares memory with the reader input.
+func (r *Reader) ReadRaw() ([]byte, error) {
+	start := r.offset
+	if err := r.Skip(); err != nil {
+		return nil, err
+	}
+
+	return r.input[start:r.offset:r.offset], nil
+}
+
+func (r *Reader) peekByte() (byte, error) {
+	if r.offset >= len(r.input) {
+		return 0, fmt.Errorf("read out of bounds")
+	}
+	return r.input[r.offset], nil
+}
+
+func (r *Reader) skipBinary() error {
+	b, err := r.readByte()
+	if err != nil {
+		return err
+	}
+
+	var length int
+
+	switch b {
+	case Bin8:
+		l, err := r.readUint8()
+		if err != nil {
+			return err
+		}
+		length = int(l)
+	case Bin16:
+		l, err := r.readUint16()
+		if err != nil {
+			return err
+		}
+		length = int(l)
+	default:
+		l, err := r.readUint32()
+		if err != nil {
+			return err
+		}
+		length = int(l)
+	}
+
+	_, err = r.readBytes(length)
+	return err
+}
+
 // Decode decodes msgpack data into the provided value
 func (r *Reader) Decode(v any) error {
 	if v == nil {
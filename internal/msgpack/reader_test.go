@@ -647,6 +647,65 @@ func TestSerializeDeserialize(t *testing.T) {
 	}
 }
 
+func TestSkip(t *testing.T) {
+	values := []any{
+		nil, true, 1, -1, 200, -200, 70000, -70000, int64(1) << 40, uint64(1) << 63,
+		float32(1.5), 2.5, "short", string(make([]byte, 300)), []byte{1, 2, 3},
+		[]any{1, "two", []any{3.0}}, map[string]any{"a": 1, "b": map[string]any{"c": "d"}},
+	}
+
+	for _, v := range values {
+		data, err := msgpack.Marshal([]any{v, "after"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r := msgpack.NewReader(data)
+		if _, err := r.ReadArrayHeader(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := r.Skip(); err != nil {
+			t.Fatalf("value %v: %v", v, err)
+		}
+
+		after, err := r.ReadString()
+		if err != nil || after != "after" {
+			t.Fatalf("value %v: expected to land on next value, got %q, %v", v, after, err)
+		}
+	}
+}
+
+func TestReadRaw(t *testing.T) {
+	data, err := msgpack.Marshal(map[string]any{"value": []any{1, "two"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := msgpack.NewReader(data)
+	if _, err := r.ReadMapHeader(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.ReadString(); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := r.ReadRaw()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, _ := msgpack.Marshal([]any{1, "two"})
+	if !bytes.Equal(raw, expected) {
+		t.Fatalf("expected %v, got %v", expected, raw)
+	}
+
+	if _, err := msgpack.NewReader(data[:len(data)-1]).ReadRaw(); err == nil {
+		t.Fatal("expected error for truncated input")
+	}
+}
+
 // Fuzz tests
 
 func FuzzReadFloat64(f *testing.F) {
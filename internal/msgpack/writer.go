@@ -245,6 +245,11 @@ func (w *Writer) WriteBinary(data []byte) error {
 	return w.write(data)
 }
 
+// WriteRaw writes data, which must already be a valid msgpack value, as is
+func (w *Writer) WriteRaw(data []byte) error {
+	return w.write(data)
+}
+
 // WriteArrayHeader writes an array header with the given length
 func (w *Writer) WriteArrayHeader(l int) error {
 	if l <= FixarrayMax {
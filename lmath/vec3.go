@@ -0,0 +1,31 @@
+package lmath
+
+import (
+	"github.com/oriolus-software/script-go/internal/msgpack"
+)
+
+type Vec3 struct {
+	X float32 `msgpack:"x"`
+	Y float32 `msgpack:"y"`
+	Z float32 `msgpack:"z"`
+}
+
+func (v Vec3) MarshalMsgpack(w *msgpack.Writer) error {
+	if err := w.WriteArrayHeader(3); err != nil {
+		return err
+	}
+
+	if err := w.WriteFloat32(v.X); err != nil {
+		return err
+	}
+
+	if err := w.WriteFloat32(v.Y); err != nil {
+		return err
+	}
+
+	if err := w.WriteFloat32(v.Z); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+//go:build !wasm
+
+package log
+
+import (
+	"github.com/oriolus-software/script-go/internal/ffi"
+	"github.com/oriolus-software/script-go/internal/host"
+)
+
+func write(level int, message uint64) {
+	host.Current.Write(level, ffi.Deserialize[string](message))
+}
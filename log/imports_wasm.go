@@ -0,0 +1,7 @@
+//go:build wasm
+
+package log
+
+//go:wasm-module log
+//export write
+func write(level int, message uint64)
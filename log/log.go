@@ -49,7 +49,3 @@ func writeTrampoline(level int, message string) {
 	m := ffi.Serialize(message)
 	write(level, m.ToPacked())
 }
-
-//go:wasm-module log
-//export write
-func write(level int, message uint64)
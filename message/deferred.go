@@ -0,0 +1,110 @@
+package message
+
+import (
+	"github.com/oriolus-software/script-go/internal/ffi"
+	"github.com/oriolus-software/script-go/internal/msgpack"
+)
+
+type deferredMessage struct {
+	message *RawMessage
+	// encoded is the message as it is passed to the host.
+	encoded ffi.Prepared
+	targets []any
+	// key identifies the target set, it is the serialized targets.
+	key string
+}
+
+type coalesceKey struct {
+	meta    Meta
+	targets string
+}
+
+var (
+	deferring bool
+	deferred  []deferredMessage
+	coalesced = make(map[coalesceKey]int)
+	coalesce  = make(map[Meta]bool)
+)
+
+// SetDeferred turns deferred sending of outgoing messages on or off. While
+// it is on, sent messages are encoded and queued, and only passed to the
+// host by FlushDeferred, which the script package calls at the end of every
+// frame. Turning it off flushes the queue.
+//
+// Deferring does not batch host calls: the host has no import taking more
+// than one message, so every queued message is one call when it is flushed.
+// Only messages dropped by Coalesce save host calls. Queued messages are
+// encoded once, when they are sent, like messages that are not deferred.
+func SetDeferred(enabled bool) {
+	if !enabled {
+		FlushDeferred()
+	}
+
+	deferring = enabled
+}
+
+// Coalesce marks T as a state-like message: while sending is deferred, only
+// the last message of type T sent to the same targets within a frame is
+// sent. It keeps the position of the first one.
+func Coalesce[T Message]() {
+	var proto T
+	coalesce[proto.Meta()] = true
+}
+
+// FlushDeferred sends all queued messages in the order they were sent.
+func FlushDeferred() {
+	if len(deferred) == 0 {
+		return
+	}
+
+	queued := deferred
+	deferred = nil
+	clear(coalesced)
+
+	for _, d := range queued {
+		if recording != nil {
+			recordOutgoing(d.message, d.targets)
+		}
+
+		send(ffi.Prepared(d.key).ToPacked(), d.encoded.ToPacked())
+	}
+}
+
+// enqueue queues message for FlushDeferred. The message is encoded right
+// away and only once, so later changes to the sent value do not change it
+// and flushing does not encode it again.
+func enqueue(message *RawMessage, targets []any) {
+	key := string(ffi.Prepare(targets))
+
+	queued := &RawMessage{Meta: message.Meta}
+	if message.Payload != nil {
+		raw, err := msgpack.Marshal(message.Payload)
+		if err != nil {
+			panic(err)
+		}
+
+		queued.Raw = raw
+	} else {
+		queued.Raw = append([]byte(nil), message.Raw...)
+	}
+
+	encoded := ffi.Prepare(queued)
+
+	if coalesce[message.Meta] {
+		ck := coalesceKey{meta: message.Meta, targets: key}
+		if i, ok := coalesced[ck]; ok {
+			deferred[i].message = queued
+			deferred[i].encoded = encoded
+			return
+		}
+
+		coalesced[ck] = len(deferred)
+	}
+
+	deferred = append(deferred, deferredMessage{
+		message: queued,
+		encoded: encoded,
+		targets: targets,
+		key:     key,
+	})
+}
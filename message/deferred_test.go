@@ -0,0 +1,79 @@
+package message_test
+
+import (
+	"testing"
+
+	"github.com/oriolus-software/script-go/message"
+	"github.com/oriolus-software/script-go/scripttest"
+)
+
+type carState struct {
+	Speed float64 `msgpack:"speed"`
+}
+
+func (carState) Meta() message.Meta {
+	return message.Meta{Namespace: "deferred", Identifier: "car_state"}
+}
+
+type horn struct{}
+
+func (horn) Meta() message.Meta {
+	return message.Meta{Namespace: "deferred", Identifier: "horn"}
+}
+
+func TestDeferred(t *testing.T) {
+	rt := scripttest.New(t)
+
+	message.Coalesce[carState]()
+	message.SetDeferred(true)
+	defer message.SetDeferred(false)
+
+	rt.Export("tick", func() {
+		message.Send(carState{Speed: 1}, message.Parent)
+		message.Send(horn{}, message.Parent)
+		message.Send(carState{Speed: 2}, message.ChildByIndex(0))
+		message.Send(horn{}, message.Parent)
+		message.Send(carState{Speed: 3}, message.Parent)
+
+		if len(rt.Sent()) != 0 {
+			t.Fatal("expected messages to be queued during the tick")
+		}
+	})
+
+	rt.Tick()
+
+	sent := rt.Sent()
+	if len(sent) != 4 {
+		t.Fatalf("expected 4 messages after coalescing, got %d", len(sent))
+	}
+
+	var first carState
+	if err := sent[0].Decode(&first); err != nil || first.Speed != 3 {
+		t.Fatalf("expected the last state for the parent in first position, got %+v, %v", first, err)
+	}
+
+	states := scripttest.SentOf[carState](rt)
+	if len(states) != 2 || states[1].Speed != 2 {
+		t.Fatalf("expected the child state to be kept, got %v", states)
+	}
+}
+
+func TestDeferredEncodesOnSend(t *testing.T) {
+	rt := scripttest.New(t)
+
+	message.SetDeferred(true)
+	defer message.SetDeferred(false)
+
+	rt.Export("tick", func() {
+		state := &carState{Speed: 1}
+		message.Send(state, message.Parent)
+		state.Speed = 2
+	})
+
+	rt.Tick()
+
+	states := scripttest.SentOf[carState](rt)
+	if len(states) != 1 || states[0].Speed != 1 {
+		t.Fatalf("expected the state as it was sent, got %v", states)
+	}
+}
@@ -0,0 +1,69 @@
+package message
+
+import (
+	"github.com/oriolus-software/script-go/log"
+)
+
+var (
+	decodeErrorHook = logDecodeError
+	dropped         = make(map[Meta]uint64)
+)
+
+// OnDecodeError sets the function called when the payload of a received
+// message cannot be decoded into the type its handler expects. Passing nil
+// restores the default, which logs the error.
+func OnDecodeError(hook func(Meta, error)) {
+	if hook == nil {
+		hook = logDecodeError
+	}
+
+	decodeErrorHook = hook
+}
+
+// Dropped returns how many messages with meta were dropped because their
+// payload could not be decoded.
+func Dropped(meta Meta) uint64 {
+	return dropped[meta]
+}
+
+// DroppedCounts returns the number of dropped messages for every meta that
+// had at least one.
+func DroppedCounts() map[Meta]uint64 {
+	counts := make(map[Meta]uint64, len(dropped))
+	for meta, count := range dropped {
+		counts[meta] = count
+	}
+
+	return counts
+}
+
+// decodeFailed notes that a handler could not decode the payload of
+// message. The message counts as dropped once all handlers ran, see
+// reportDropped.
+func decodeFailed(message *RawMessage, err error) {
+	if message.decodeErr == nil {
+		message.decodeErr = err
+	}
+}
+
+// reportDropped counts message as dropped and reports the first decode
+// error if no handler could decode its payload. Messages that at least one
+// handler decoded are not dropped, e.g. when handlers of an older and a
+// newer payload type share a meta.
+func reportDropped(message *RawMessage) {
+	if message.decodeErr == nil || message.decoded {
+		return
+	}
+
+	dropped[message.Meta]++
+	decodeErrorHook(message.Meta, message.decodeErr)
+}
+
+func logDecodeError(meta Meta, err error) {
+	if meta.Bus != "" {
+		log.Errorf("dropped message %s.%s on bus %s: %v", meta.Namespace, meta.Identifier, meta.Bus, err)
+		return
+	}
+
+	log.Errorf("dropped message %s.%s: %v", meta.Namespace, meta.Identifier, err)
+}
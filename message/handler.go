@@ -1,25 +1,41 @@
 package message
 
 import (
+	"github.com/oriolus-software/script-go/internal/exports"
 	"github.com/oriolus-software/script-go/internal/ffi"
 	"github.com/oriolus-software/script-go/internal/msgpack"
+	"github.com/oriolus-software/script-go/time"
 )
 
-var handlers = make(map[Meta]func(*RawMessage))
+// Handler processes a received message.
+type Handler func(*RawMessage)
 
-func RegisterHandler[T Message](handler func(Incoming[T])) {
-	var proto T
+// Middleware wraps the delivery of every received message, e.g. to log,
+// filter or rate limit messages. Calling next passes the message on, not
+// calling it drops the message.
+type Middleware func(next Handler) Handler
 
-	handlers[proto.Meta()] = func(message *RawMessage) {
-		// TODO: Do not marshal and unmarshal message payload
-		raw, err := msgpack.Marshal(&message.Payload)
-		if err != nil {
-			return
-		}
+// Subscription is a handler registered for one message type, or for every
+// message matching a predicate.
+type Subscription struct {
+	meta    Meta
+	match   func(Meta) bool
+	handler Handler
+}
 
-		var data T
-		err = msgpack.Unmarshal(raw, &data)
-		if err != nil {
+var (
+	handlers    = make(map[Meta][]*Subscription)
+	wildcards   []*Subscription
+	middlewares []*MiddlewareHandle
+	dispatch    Handler = deliver
+)
+
+func RegisterHandler[T Message](handler func(Incoming[T])) *Subscription {
+	var proto T
+
+	return subscribe(proto.Meta(), func(message *RawMessage) {
+		data, ok := decode[T](message)
+		if !ok {
 			return
 		}
 
@@ -28,6 +44,131 @@ func RegisterHandler[T Message](handler func(Incoming[T])) {
 			Source:  &message.Source,
 			Payload: &data,
 		})
+	})
+}
+
+// decode decodes the payload of message into a T. Every handler decodes a
+// payload of its own, so handlers cannot see changes other handlers made to
+// slices, maps or pointers in it.
+func decode[T any](message *RawMessage) (T, bool) {
+	var data T
+	if err := msgpack.Unmarshal(message.Raw, &data); err != nil {
+		decodeFailed(message, err)
+		return data, false
+	}
+
+	message.decoded = true
+	return data, true
+}
+
+// SubscribeNamespace registers handler for every message in namespace,
+// whatever its identifier and bus.
+func SubscribeNamespace(namespace string, handler Handler) *Subscription {
+	return SubscribeFunc(func(meta Meta) bool {
+		return meta.Namespace == namespace
+	}, handler)
+}
+
+// SubscribeBus registers handler for every message sent on bus.
+func SubscribeBus(bus string, handler Handler) *Subscription {
+	return SubscribeFunc(func(meta Meta) bool {
+		return meta.Bus == bus
+	}, handler)
+}
+
+// SubscribeFunc registers handler for every message whose meta satisfies
+// match. The handler gets the payload undecoded, see RawMessage.Decode.
+func SubscribeFunc(match func(Meta) bool, handler Handler) *Subscription {
+	s := &Subscription{match: match, handler: handler}
+	wildcards = append(wildcards, s)
+	return s
+}
+
+// Unsubscribe removes the handler. It is safe to call during dispatch and
+// more than once.
+func (s *Subscription) Unsubscribe() {
+	if s.match != nil {
+		wildcards = without(wildcards, s)
+		return
+	}
+
+	rest := without(handlers[s.meta], s)
+	if len(rest) == 0 {
+		delete(handlers, s.meta)
+	} else {
+		handlers[s.meta] = rest
+	}
+}
+
+// without returns subs without s. It copies instead of shifting in place,
+// since a dispatch in progress may still iterate the old slice.
+func without(subs []*Subscription, s *Subscription) []*Subscription {
+	for i, other := range subs {
+		if other != s {
+			continue
+		}
+
+		rest := make([]*Subscription, 0, len(subs)-1)
+		rest = append(rest, subs[:i]...)
+		return append(rest, subs[i+1:]...)
+	}
+
+	return subs
+}
+
+// MiddlewareHandle is a middleware added by Use.
+type MiddlewareHandle struct {
+	middleware Middleware
+}
+
+// Use adds a middleware. Middlewares run in the order they were added, the
+// first one being the outermost.
+func Use(middleware Middleware) *MiddlewareHandle {
+	m := &MiddlewareHandle{middleware: middleware}
+	middlewares = append(middlewares, m)
+	chain()
+	return m
+}
+
+// Remove removes the middleware. It is safe to call during dispatch and more
+// than once.
+func (m *MiddlewareHandle) Remove() {
+	for i, other := range middlewares {
+		if other != m {
+			continue
+		}
+
+		rest := make([]*MiddlewareHandle, 0, len(middlewares)-1)
+		rest = append(rest, middlewares[:i]...)
+		middlewares = append(rest, middlewares[i+1:]...)
+		chain()
+		return
+	}
+}
+
+// chain rebuilds dispatch from the added middlewares.
+func chain() {
+	dispatch = deliver
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		dispatch = middlewares[i].middleware(dispatch)
+	}
+}
+
+func subscribe(meta Meta, handler Handler) *Subscription {
+	s := &Subscription{meta: meta, handler: handler}
+	handlers[meta] = append(handlers[meta], s)
+	return s
+}
+
+func deliver(message *RawMessage) {
+	for _, s := range handlers[message.Meta] {
+		s.handler(message)
+	}
+
+	for _, s := range wildcards {
+		if s.match(message.Meta) {
+			s.handler(message)
+		}
 	}
 }
 
@@ -37,17 +178,59 @@ type Incoming[T Message] struct {
 	Payload *T
 }
 
+// Reply sends message back to the sender of the incoming message. It
+// returns ErrUnreachable without sending if no target reaches the sender,
+// see ReplyTo.
+func (i Incoming[T]) Reply(message Message) error {
+	target, err := ReplyTo(*i.Source)
+	if err != nil {
+		return err
+	}
+
+	Send(message, target)
+	return nil
+}
+
+// late_tick dispatches the received messages, unless the script package
+// runs its own late tick, which does so as well.
+//
 //export late_tick
 func late_tick() {
-	messages := ffi.Deserialize[[]RawMessage](take())
+	if exports.LateTick != nil {
+		exports.LateTick()
+		return
+	}
+
+	Dispatch()
+}
 
-	for _, message := range messages {
+// Dispatch takes the messages received since the last call from the host
+// and passes them to the registered handlers.
+func Dispatch() {
+	// The batch is copied out of host memory once, since handlers calling
+	// into the host may overwrite it while raw payloads still point into it.
+	var messages []RawMessage
+	if err := msgpack.Unmarshal(ffi.Bytes(take()), &messages); err != nil {
+		panic(err)
+	}
 
-		handler, ok := handlers[message.Meta]
-		if !ok {
-			continue
+	if replay != nil {
+		messages = append(replay.next(time.TicksAlive()), messages...)
+		if len(replay.messages) == 0 {
+			replay = nil
 		}
+	}
+
+	if recording != nil {
+		recordIncoming(messages)
+	}
 
-		handler(&message)
+	// Payloads stay encoded until a handler decodes them, so messages
+	// without a handler are never materialized.
+	for i := range messages {
+		dispatch(&messages[i])
+		reportDropped(&messages[i])
 	}
+
+	expireRequests()
 }
@@ -0,0 +1,272 @@
+package message_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/oriolus-software/script-go/internal/exports"
+	"github.com/oriolus-software/script-go/message"
+	"github.com/oriolus-software/script-go/scripttest"
+)
+
+type brakeDemand struct {
+	Force float64 `msgpack:"force"`
+}
+
+func (brakeDemand) Meta() message.Meta {
+	return message.Meta{Namespace: "brake", Identifier: "demand"}
+}
+
+// brakeDemandV2 shares the meta of brakeDemand with an incompatible schema.
+type brakeDemandV2 struct {
+	Force string `msgpack:"force"`
+}
+
+func (brakeDemandV2) Meta() message.Meta {
+	return brakeDemand{}.Meta()
+}
+
+func TestDecodeError(t *testing.T) {
+	rt := scripttest.New(t)
+
+	handled := 0
+	for i := 0; i < 2; i++ {
+		sub := message.RegisterHandler(func(message.Incoming[brakeDemand]) {
+			handled++
+		})
+		t.Cleanup(sub.Unsubscribe)
+	}
+
+	before := message.Dropped(brakeDemand{}.Meta())
+	rt.Deliver(brakeDemandV2{Force: "full"}, message.MessageSource{})
+	rt.Deliver(brakeDemand{Force: 1}, message.MessageSource{})
+	rt.Tick()
+
+	if handled != 2 {
+		t.Fatalf("expected both handlers to handle 1 message, got %d calls", handled)
+	}
+
+	if got := message.Dropped(brakeDemand{}.Meta()) - before; got != 1 {
+		t.Fatalf("expected 1 dropped message, got %d", got)
+	}
+
+	logs := rt.Logs()
+	if len(logs) != 1 || !strings.HasPrefix(logs[0].Message, "dropped message brake.demand: ") {
+		t.Fatalf("expected decode error to be logged, got %v", logs)
+	}
+
+	var hooked error
+	message.OnDecodeError(func(meta message.Meta, err error) {
+		hooked = err
+	})
+	defer message.OnDecodeError(nil)
+
+	rt.Deliver(brakeDemandV2{Force: "full"}, message.MessageSource{})
+	rt.Tick()
+
+	if hooked == nil {
+		t.Fatalf("expected hook to receive the decode error, got %v", hooked)
+	}
+
+	if len(rt.Logs()) != 1 {
+		t.Fatalf("expected hook to replace logging, got %v", rt.Logs())
+	}
+}
+
+func TestDecodeErrorWithCompatibleHandler(t *testing.T) {
+	rt := scripttest.New(t)
+
+	handled := 0
+	sub := message.RegisterHandler(func(message.Incoming[brakeDemand]) {})
+	t.Cleanup(sub.Unsubscribe)
+	other := message.RegisterHandler(func(message.Incoming[brakeDemandV2]) {
+		handled++
+	})
+	t.Cleanup(other.Unsubscribe)
+
+	before := message.Dropped(brakeDemand{}.Meta())
+	rt.Deliver(brakeDemandV2{Force: "full"}, message.MessageSource{})
+	rt.Tick()
+
+	if handled != 1 {
+		t.Fatalf("expected the compatible handler to run, got %d calls", handled)
+	}
+
+	if got := message.Dropped(brakeDemand{}.Meta()) - before; got != 0 {
+		t.Fatalf("expected no dropped message, got %d", got)
+	}
+
+	if logs := rt.Logs(); len(logs) != 0 {
+		t.Fatalf("expected no decode error to be logged, got %v", logs)
+	}
+}
+
+type stopList struct {
+	Stops []string `msgpack:"stops"`
+}
+
+func (stopList) Meta() message.Meta {
+	return message.Meta{Namespace: "ibis", Identifier: "stops"}
+}
+
+func TestHandlersGetOwnPayload(t *testing.T) {
+	rt := scripttest.New(t)
+
+	var seen []string
+	for i := 0; i < 2; i++ {
+		sub := message.RegisterHandler(func(in message.Incoming[stopList]) {
+			seen = append(seen, in.Payload.Stops[0])
+			in.Payload.Stops[0] = "changed"
+		})
+		t.Cleanup(sub.Unsubscribe)
+	}
+
+	rt.Deliver(stopList{Stops: []string{"Hauptbahnhof"}}, message.MessageSource{})
+	rt.Tick()
+
+	if len(seen) != 2 || seen[1] != "Hauptbahnhof" {
+		t.Fatalf("expected every handler to see the sent stops, got %v", seen)
+	}
+}
+
+type doorCommand struct {
+	Open bool `msgpack:"open"`
+}
+
+func (doorCommand) Meta() message.Meta {
+	return message.Meta{Namespace: "doors", Identifier: "command"}
+}
+
+func TestMultipleHandlers(t *testing.T) {
+	rt := scripttest.New(t)
+
+	var first, second int
+	sub := message.RegisterHandler(func(message.Incoming[doorCommand]) {
+		first++
+	})
+	defer sub.Unsubscribe()
+
+	other := message.RegisterHandler(func(message.Incoming[doorCommand]) {
+		second++
+	})
+
+	var coupled []string
+	filter := message.Use(func(next message.Handler) message.Handler {
+		return func(m *message.RawMessage) {
+			if m.Meta == (doorCommand{}).Meta() && m.Source.Coupling != "" {
+				coupled = append(coupled, m.Source.Coupling)
+				return
+			}
+
+			next(m)
+		}
+	})
+
+	rt.Deliver(doorCommand{Open: true}, message.MessageSource{})
+	rt.Deliver(doorCommand{Open: true}, message.MessageSource{Coupling: "rear"})
+	rt.Tick()
+
+	if first != 1 || second != 1 {
+		t.Fatalf("expected both handlers to run once, got %d and %d", first, second)
+	}
+
+	if len(coupled) != 1 || coupled[0] != "rear" {
+		t.Fatalf("expected middleware to filter the coupled message, got %v", coupled)
+	}
+
+	other.Unsubscribe()
+	other.Unsubscribe()
+
+	rt.Deliver(doorCommand{}, message.MessageSource{})
+	rt.Tick()
+
+	if first != 2 || second != 1 {
+		t.Fatalf("expected only the first handler to run, got %d and %d", first, second)
+	}
+
+	filter.Remove()
+	filter.Remove()
+
+	rt.Deliver(doorCommand{}, message.MessageSource{Coupling: "rear"})
+	rt.Tick()
+
+	if first != 3 || len(coupled) != 1 {
+		t.Fatalf("expected the removed middleware to pass messages on, got %d calls and %v", first, coupled)
+	}
+}
+
+type ibisRoute struct {
+	Line string `msgpack:"line"`
+}
+
+func (ibisRoute) Meta() message.Meta {
+	return message.Meta{Namespace: "ibis", Identifier: "route"}
+}
+
+type ibisStop struct {
+	Name string `msgpack:"name"`
+}
+
+func (ibisStop) Meta() message.Meta {
+	return message.Meta{Namespace: "ibis", Identifier: "stop", Bus: "ibis"}
+}
+
+func TestWildcardSubscriptions(t *testing.T) {
+	rt := scripttest.New(t)
+
+	bridge := message.SubscribeNamespace("ibis", func(m *message.RawMessage) {
+		message.SendRaw(m, message.AcrossCoupling{Coupling: "rear"})
+	})
+	defer bridge.Unsubscribe()
+
+	var onBus []string
+	bus := message.SubscribeBus("ibis", func(m *message.RawMessage) {
+		var stop ibisStop
+		if err := m.Decode(&stop); err != nil {
+			t.Fatal(err)
+		}
+		onBus = append(onBus, stop.Name)
+	})
+	defer bus.Unsubscribe()
+
+	rt.Deliver(ibisRoute{Line: "12"}, message.MessageSource{})
+	rt.Deliver(ibisStop{Name: "Hauptbahnhof"}, message.MessageSource{})
+	rt.Deliver(doorCommand{}, message.MessageSource{})
+	rt.Tick()
+
+	if len(onBus) != 1 || onBus[0] != "Hauptbahnhof" {
+		t.Fatalf("expected the stop on the ibis bus, got %v", onBus)
+	}
+
+	routes := scripttest.SentOf[ibisRoute](rt)
+	if len(routes) != 1 || routes[0].Line != "12" {
+		t.Fatalf("expected the route to be forwarded, got %v", routes)
+	}
+
+	if sent := rt.Sent(); len(sent) != 2 {
+		t.Fatalf("expected 2 forwarded messages, got %d", len(sent))
+	}
+}
+
+func TestLateTickWithoutScriptPackage(t *testing.T) {
+	rt := scripttest.New(t)
+
+	// Modules not linking the script package dispatch from the late_tick
+	// export of this package.
+	lateTick := exports.LateTick
+	exports.LateTick = nil
+	t.Cleanup(func() { exports.LateTick = lateTick })
+
+	var got []brakeDemand
+	sub := message.RegisterHandler(func(in message.Incoming[brakeDemand]) {
+		got = append(got, *in.Payload)
+	})
+	t.Cleanup(sub.Unsubscribe)
+
+	rt.Deliver(brakeDemand{Force: 0.5}, message.MessageSource{ModuleSlotIndex: -1, ModuleSlotCockpitIndex: -1})
+	rt.Tick()
+
+	if len(got) != 1 || got[0].Force != 0.5 {
+		t.Fatalf("expected the message to be dispatched, got %v", got)
+	}
+}
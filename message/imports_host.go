@@ -0,0 +1,45 @@
+//go:build !wasm
+
+package message
+
+import (
+	"github.com/oriolus-software/script-go/internal/ffi"
+	"github.com/oriolus-software/script-go/internal/host"
+)
+
+func take() uint64 {
+	return ffi.Serialize(host.Current.Take()).ToPacked()
+}
+
+func send(targets, message uint64) {
+	host.Current.Send(ffi.Deserialize[[]any](targets), ffi.Deserialize[map[string]any](message))
+}
+
+func init() {
+	host.Export("late_tick", late_tick)
+	host.OnReset(reset)
+}
+
+// reset drops the handlers, middlewares, timers and other state a test left
+// behind, see scripttest.New.
+func reset() {
+	handlers = make(map[Meta][]*Subscription)
+	wildcards = nil
+	middlewares = nil
+	dispatch = deliver
+
+	timers = nil
+	pending = make(map[uint64]*pendingRequest)
+	responseMetas = make(map[Meta]bool)
+
+	deferring = false
+	deferred = nil
+	coalesced = make(map[coalesceKey]int)
+	coalesce = make(map[Meta]bool)
+
+	recording = nil
+	replay = nil
+
+	decodeErrorHook = logDecodeError
+	dropped = make(map[Meta]uint64)
+}
@@ -0,0 +1,11 @@
+//go:build wasm
+
+package message
+
+//go:wasm-module messages
+//export take
+func take() uint64
+
+//go:wasm-module messages
+//export send
+func send(targets, message uint64)
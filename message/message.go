@@ -15,6 +15,52 @@ type RawMessage struct {
 	Meta    Meta          `msgpack:"meta"`
 	Source  MessageSource `msgpack:"source"`
 	Payload any           `msgpack:"value"`
+	// Raw holds the still encoded payload of a received message. It shares
+	// memory with the whole batch of received messages, copy it to keep it
+	// after dispatch.
+	Raw []byte
+
+	// decoded is set once a handler decoded the payload.
+	decoded bool
+	// decodeErr is the first error of a handler failing to decode the
+	// payload.
+	decodeErr error
+}
+
+// Decode decodes the raw payload of a received message into v.
+func (m *RawMessage) Decode(v any) error {
+	return msgpack.NewReader(m.Raw).Decode(v)
+}
+
+func (m *RawMessage) UnmarshalMsgpack(r *msgpack.Reader) error {
+	h, err := r.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < h; i++ {
+		key, err := r.ReadString()
+		if err != nil {
+			return err
+		}
+
+		switch key {
+		case "meta":
+			err = r.Decode(&m.Meta)
+		case "source":
+			err = r.Decode(&m.Source)
+		case "value":
+			m.Raw, err = r.ReadRaw()
+		default:
+			err = r.Skip()
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (m *RawMessage) MarshalMsgpack(w *msgpack.Writer) error {
@@ -63,6 +109,10 @@ func (m *RawMessage) MarshalMsgpack(w *msgpack.Writer) error {
 		return err
 	}
 
+	if m.Payload == nil && m.Raw != nil {
+		return w.WriteRaw(m.Raw)
+	}
+
 	if err := w.Encode(m.Payload); err != nil {
 		return err
 	}
@@ -71,9 +121,9 @@ func (m *RawMessage) MarshalMsgpack(w *msgpack.Writer) error {
 }
 
 type MessageSource struct {
-	Coupling               string `msgpack:"coupling"`                // "" if not set
-	ModuleSlotIndex        int    `msgpack:"module_slot_index"`       // -1 if not set
-	ModuleSlotCockpitIndex int    `msgpack:"module_slot_cockpit_idx"` // -1 if not set
+	Coupling               string `msgpack:"coupling"`                  // "" if not set
+	ModuleSlotIndex        int    `msgpack:"module_slot_index"`         // -1 if not set
+	ModuleSlotCockpitIndex int    `msgpack:"module_slot_cockpit_index"` // -1 if not set
 }
 
 func (m *MessageSource) UnmarshalMsgpack(r *msgpack.Reader) error {
@@ -107,6 +157,10 @@ func (m *MessageSource) UnmarshalMsgpack(r *msgpack.Reader) error {
 			if err == nil {
 				m.ModuleSlotCockpitIndex = int(idx)
 			}
+		default:
+			if err := r.Skip(); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -118,24 +172,38 @@ type Message interface {
 }
 
 func Send(message Message, targets ...Target) {
+	post(&RawMessage{
+		Meta:    message.Meta(),
+		Payload: message,
+	}, targets)
+}
+
+// SendRaw sends a received message on to targets, keeping its meta and its
+// still encoded payload. It allows forwarding messages without knowing
+// their type.
+func SendRaw(message *RawMessage, targets ...Target) {
+	post(&RawMessage{
+		Meta: message.Meta,
+		Raw:  message.Raw,
+	}, targets)
+}
+
+func post(message *RawMessage, targets []Target) {
 	tgts := make([]any, len(targets))
 	for i, target := range targets {
 		tgts[i] = target.toMessageTarget()
 	}
 
-	meta := message.Meta()
-	m := ffi.Serialize(&RawMessage{
-		Meta:    meta,
-		Payload: message,
-	})
+	if deferring {
+		enqueue(message, tgts)
+		return
+	}
+
+	if recording != nil {
+		recordOutgoing(message, tgts)
+	}
+
+	m := ffi.Serialize(message)
 	t := ffi.Serialize(tgts)
 	send(t.ToPacked(), m.ToPacked())
 }
-
-//go:wasm-module messages
-//export take
-func take() uint64
-
-//go:wasm-module messages
-//export send
-func send(targets, message uint64)
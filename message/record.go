@@ -0,0 +1,315 @@
+package message
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/oriolus-software/script-go/internal/msgpack"
+	"github.com/oriolus-software/script-go/log"
+	"github.com/oriolus-software/script-go/time"
+	"github.com/oriolus-software/script-go/vars"
+)
+
+// RecordedMessage is a message captured by the recorder.
+type RecordedMessage struct {
+	Tick     uint64
+	Outgoing bool
+	Meta     Meta
+	// Source is set for incoming messages.
+	Source MessageSource
+	// Targets is set for outgoing messages and holds the targets as they
+	// were sent to the host.
+	Targets []any
+	// Payload is the encoded message payload. It is nil for an outgoing
+	// message whose payload could not be encoded.
+	Payload []byte
+}
+
+// Decode decodes the recorded payload into v.
+func (m *RecordedMessage) Decode(v any) error {
+	return msgpack.NewReader(m.Payload).Decode(v)
+}
+
+// Recording is a log of the messages a script received and sent.
+type Recording struct {
+	Messages []RecordedMessage
+	// Discarded counts the oldest messages dropped to stay within
+	// RecordingLimit.
+	Discarded uint64
+}
+
+// RecordingLimit is the number of messages a recording keeps. Once it is
+// reached, every new message discards the oldest one.
+var RecordingLimit = 4096
+
+func (r *Recording) add(m RecordedMessage) {
+	if n := len(r.Messages) + 1 - RecordingLimit; n > 0 {
+		n = min(n, len(r.Messages))
+		r.Messages = r.Messages[n:]
+		r.Discarded += uint64(n)
+	}
+
+	r.Messages = append(r.Messages, m)
+}
+
+var (
+	recording *Recording
+	replay    *replayer
+)
+
+// StartRecording starts capturing every received and sent message,
+// discarding a recording still in progress.
+func StartRecording() {
+	recording = &Recording{}
+}
+
+// StopRecording stops capturing messages and returns what was captured, or
+// nil if no recording was in progress.
+func StopRecording() *Recording {
+	r := recording
+	recording = nil
+	return r
+}
+
+// Encode returns the recording in its compact msgpack form.
+func (r *Recording) Encode() []byte {
+	data, err := msgpack.Marshal(r.Messages)
+	if err != nil {
+		panic(err)
+	}
+
+	return data
+}
+
+// Dump returns the encoded recording as base64, suitable for string
+// variables and log lines.
+func (r *Recording) Dump() string {
+	return base64.StdEncoding.EncodeToString(r.Encode())
+}
+
+// DumpToVar stores the dump of the recording in the string variable name.
+func (r *Recording) DumpToVar(name string) {
+	vars.SetString(name, r.Dump())
+}
+
+// DumpToLog writes the dump of the recording to the log.
+func (r *Recording) DumpToLog() {
+	log.Infof("message recording: %s", r.Dump())
+}
+
+// DecodeRecording decodes a recording produced by Encode.
+func DecodeRecording(data []byte) (*Recording, error) {
+	r := &Recording{}
+	if err := msgpack.Unmarshal(data, &r.Messages); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ParseRecording decodes a recording produced by Dump.
+func ParseRecording(dump string) (*Recording, error) {
+	data, err := base64.StdEncoding.DecodeString(dump)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeRecording(data)
+}
+
+// Replay feeds the incoming messages of r back through Dispatch. The first
+// recorded tick is replayed by the next Dispatch and later ticks keep their
+// distance to it. Messages received from the host meanwhile are dispatched
+// after the replayed messages of the same tick.
+func Replay(r *Recording) {
+	replay = &replayer{}
+	for _, m := range r.Messages {
+		if !m.Outgoing {
+			replay.messages = append(replay.messages, m)
+		}
+	}
+}
+
+// Replaying reports whether a replay is in progress.
+func Replaying() bool {
+	return replay != nil
+}
+
+type replayer struct {
+	messages []RecordedMessage
+	started  bool
+	offset   uint64
+}
+
+// next returns the recorded messages due at tick now.
+func (r *replayer) next(now uint64) []RawMessage {
+	if len(r.messages) == 0 {
+		return nil
+	}
+
+	if !r.started {
+		r.started = true
+		r.offset = now - r.messages[0].Tick
+	}
+
+	var due []RawMessage
+	for len(r.messages) > 0 && r.messages[0].Tick+r.offset <= now {
+		m := r.messages[0]
+		r.messages = r.messages[1:]
+
+		due = append(due, RawMessage{
+			Meta:   m.Meta,
+			Source: m.Source,
+			Raw:    m.Payload,
+		})
+	}
+
+	return due
+}
+
+func recordIncoming(messages []RawMessage) {
+	tick := time.TicksAlive()
+	for _, m := range messages {
+		recording.add(RecordedMessage{
+			Tick:    tick,
+			Meta:    m.Meta,
+			Source:  m.Source,
+			Payload: append([]byte(nil), m.Raw...),
+		})
+	}
+}
+
+func recordOutgoing(message *RawMessage, targets []any) {
+	payload := message.Raw
+	if message.Payload != nil {
+		var err error
+		payload, err = msgpack.Marshal(message.Payload)
+		if err != nil {
+			// The message is still recorded, only without its payload.
+			log.Errorf("recording message %s.%s: %v", message.Meta.Namespace, message.Meta.Identifier, err)
+			payload = nil
+		}
+	}
+
+	recording.add(RecordedMessage{
+		Tick:     time.TicksAlive(),
+		Outgoing: true,
+		Meta:     message.Meta,
+		Targets:  targets,
+		Payload:  append([]byte(nil), payload...),
+	})
+}
+
+// MarshalMsgpack writes the message as an array instead of a map to keep
+// recordings compact:
+// [tick, outgoing, [namespace, identifier, bus], source, targets, payload]
+func (m *RecordedMessage) MarshalMsgpack(w *msgpack.Writer) error {
+	if err := w.WriteArrayHeader(6); err != nil {
+		return err
+	}
+
+	if err := w.WriteUint(m.Tick); err != nil {
+		return err
+	}
+
+	if err := w.WriteBool(m.Outgoing); err != nil {
+		return err
+	}
+
+	if err := w.WriteArrayHeader(3); err != nil {
+		return err
+	}
+
+	for _, s := range []string{m.Meta.Namespace, m.Meta.Identifier, m.Meta.Bus} {
+		if err := w.WriteString(s); err != nil {
+			return err
+		}
+	}
+
+	if m.Outgoing {
+		if err := w.WriteNil(); err != nil {
+			return err
+		}
+	} else {
+		if err := w.WriteArrayHeader(3); err != nil {
+			return err
+		}
+
+		if err := w.WriteString(m.Source.Coupling); err != nil {
+			return err
+		}
+
+		if err := w.WriteInt(int64(m.Source.ModuleSlotIndex)); err != nil {
+			return err
+		}
+
+		if err := w.WriteInt(int64(m.Source.ModuleSlotCockpitIndex)); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Encode(m.Targets); err != nil {
+		return err
+	}
+
+	if len(m.Payload) == 0 {
+		return w.WriteNil()
+	}
+
+	return w.WriteRaw(m.Payload)
+}
+
+func (m *RecordedMessage) UnmarshalMsgpack(r *msgpack.Reader) error {
+	l, err := r.ReadArrayHeader()
+	if err != nil {
+		return err
+	}
+
+	if l != 6 {
+		return fmt.Errorf("expected 6 elements, got %d", l)
+	}
+
+	if m.Tick, err = r.ReadUint(); err != nil {
+		return err
+	}
+
+	if m.Outgoing, err = r.ReadBool(); err != nil {
+		return err
+	}
+
+	var meta [3]string
+	if err := r.Decode(&meta); err != nil {
+		return err
+	}
+	m.Meta = Meta{Namespace: meta[0], Identifier: meta[1], Bus: meta[2]}
+
+	m.Source = MessageSource{ModuleSlotIndex: -1, ModuleSlotCockpitIndex: -1}
+	if !m.Outgoing {
+		var source [3]any
+		if err := r.Decode(&source); err != nil {
+			return err
+		}
+
+		m.Source.Coupling, _ = source[0].(string)
+		if idx, ok := source[1].(int64); ok {
+			m.Source.ModuleSlotIndex = int(idx)
+		}
+		if idx, ok := source[2].(int64); ok {
+			m.Source.ModuleSlotCockpitIndex = int(idx)
+		}
+	} else if err := r.Skip(); err != nil {
+		return err
+	}
+
+	if err := r.Decode(&m.Targets); err != nil {
+		return err
+	}
+
+	m.Payload, err = r.ReadRaw()
+	if err != nil {
+		return err
+	}
+	m.Payload = append([]byte(nil), m.Payload...)
+
+	return nil
+}
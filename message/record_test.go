@@ -0,0 +1,101 @@
+package message_test
+
+import (
+	"testing"
+
+	"github.com/oriolus-software/script-go/message"
+	"github.com/oriolus-software/script-go/scripttest"
+)
+
+type signal struct {
+	Aspect string `msgpack:"aspect"`
+}
+
+func (signal) Meta() message.Meta {
+	return message.Meta{Namespace: "record", Identifier: "signal"}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	rt := scripttest.New(t)
+
+	var seen []string
+	sub := message.RegisterHandler(func(in message.Incoming[signal]) {
+		seen = append(seen, in.Payload.Aspect)
+		message.Send(*in.Payload, message.Parent)
+	})
+	defer sub.Unsubscribe()
+
+	message.StartRecording()
+	rt.Deliver(signal{Aspect: "stop"}, message.MessageSource{Coupling: "front"})
+	rt.Tick()
+	rt.Tick()
+	rt.Deliver(signal{Aspect: "go"}, message.MessageSource{})
+	rt.Tick()
+	rec := message.StopRecording()
+
+	if len(rec.Messages) != 4 {
+		t.Fatalf("expected 2 incoming and 2 outgoing messages, got %d", len(rec.Messages))
+	}
+
+	out := rec.Messages[1]
+	if !out.Outgoing || len(out.Targets) != 1 || out.Targets[0] != "Parent" {
+		t.Fatalf("unexpected outgoing record %+v", out)
+	}
+
+	parsed, err := message.ParseRecording(rec.Dump())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := parsed.Messages[0]
+	if first.Tick != 1 || first.Source.Coupling != "front" || first.Meta != (signal{}).Meta() {
+		t.Fatalf("unexpected first record %+v", first)
+	}
+
+	var s signal
+	if err := first.Decode(&s); err != nil || s.Aspect != "stop" {
+		t.Fatalf("unexpected payload %+v, %v", s, err)
+	}
+
+	seen = nil
+	message.Replay(parsed)
+	rt.Tick()
+	if len(seen) != 1 || seen[0] != "stop" {
+		t.Fatalf("expected first tick to be replayed, got %v", seen)
+	}
+
+	rt.Deliver(signal{Aspect: "live"}, message.MessageSource{})
+	rt.Tick()
+	if len(seen) != 2 || seen[1] != "live" {
+		t.Fatalf("expected the live message on the second tick, got %v", seen)
+	}
+
+	rt.Tick()
+	if len(seen) != 3 || seen[2] != "go" || message.Replaying() {
+		t.Fatalf("expected the replay to finish with the third tick, got %v", seen)
+	}
+}
+
+func TestRecordingLimit(t *testing.T) {
+	rt := scripttest.New(t)
+
+	limit := message.RecordingLimit
+	message.RecordingLimit = 2
+	defer func() { message.RecordingLimit = limit }()
+
+	message.StartRecording()
+	for _, aspect := range []string{"stop", "caution", "go"} {
+		message.Send(signal{Aspect: aspect}, message.Parent)
+	}
+	rt.Tick()
+	rec := message.StopRecording()
+
+	if len(rec.Messages) != 2 || rec.Discarded != 1 {
+		t.Fatalf("expected 2 kept and 1 discarded message, got %d and %d", len(rec.Messages), rec.Discarded)
+	}
+
+	var s signal
+	if err := rec.Messages[0].Decode(&s); err != nil || s.Aspect != "caution" {
+		t.Fatalf("expected the oldest message to be discarded, got %+v, %v", s, err)
+	}
+}
@@ -0,0 +1,208 @@
+package message
+
+import (
+	"errors"
+
+	"github.com/oriolus-software/script-go/internal/msgpack"
+	"github.com/oriolus-software/script-go/log"
+	"github.com/oriolus-software/script-go/time"
+)
+
+// ErrTimeout is passed to a request callback when no response arrived
+// within RequestTimeout ticks.
+var ErrTimeout = errors.New("request timed out")
+
+// RequestTimeout is the number of ticks Request waits for a response.
+var RequestTimeout uint64 = 60
+
+type pendingRequest struct {
+	deadline uint64
+	resolve  func(raw []byte, err error)
+}
+
+var (
+	pending       = make(map[uint64]*pendingRequest)
+	nextRequestId uint64
+	// responseMetas holds the response types resolveResponse is subscribed
+	// to.
+	responseMetas = make(map[Meta]bool)
+)
+
+// Request sends req to target and calls callback with the response, or with
+// ErrTimeout if none arrives in time. The receiving script answers requests
+// with RegisterResponder.
+func Request[Req, Resp Message](req Req, target Target, callback func(Resp, error)) {
+	var proto Resp
+	meta := responseMeta(proto.Meta())
+	if !responseMetas[meta] {
+		responseMetas[meta] = true
+		subscribe(meta, resolveResponse)
+	}
+
+	nextRequestId++
+	id := nextRequestId
+
+	pending[id] = &pendingRequest{
+		deadline: time.TicksAlive() + RequestTimeout,
+		resolve: func(raw []byte, err error) {
+			var resp Resp
+			if err == nil {
+				err = msgpack.Unmarshal(raw, &resp)
+			}
+
+			callback(resp, err)
+		},
+	}
+
+	Send(&envelope{
+		meta:  requestMeta(req.Meta()),
+		Id:    id,
+		Value: req,
+	}, target)
+}
+
+// RegisterResponder answers every Request for Req with the value returned
+// by responder. The response is sent back to the source of the request.
+func RegisterResponder[Req, Resp Message](responder func(Incoming[Req]) Resp) *Subscription {
+	var proto Req
+
+	return subscribe(requestMeta(proto.Meta()), func(message *RawMessage) {
+		var env envelope
+		if err := message.Decode(&env); err != nil {
+			decodeFailed(message, err)
+			return
+		}
+
+		var data Req
+		if err := msgpack.Unmarshal(env.raw, &data); err != nil {
+			decodeFailed(message, err)
+			return
+		}
+		message.decoded = true
+
+		resp := responder(Incoming[Req]{
+			Meta:    &message.Meta,
+			Source:  &message.Source,
+			Payload: &data,
+		})
+
+		target, err := ReplyTo(message.Source)
+		if err != nil {
+			log.Warnf("message: cannot answer request %s.%s: %v", message.Meta.Namespace, message.Meta.Identifier, err)
+			return
+		}
+
+		Send(&envelope{
+			meta:  responseMeta(resp.Meta()),
+			Id:    env.Id,
+			Value: resp,
+		}, target)
+	})
+}
+
+func resolveResponse(message *RawMessage) {
+	var env envelope
+	if err := message.Decode(&env); err != nil {
+		decodeFailed(message, err)
+		return
+	}
+	message.decoded = true
+
+	req, ok := pending[env.Id]
+	if !ok {
+		return
+	}
+
+	delete(pending, env.Id)
+	req.resolve(env.raw, nil)
+}
+
+// expireRequests fails every pending request whose deadline has passed.
+func expireRequests() {
+	if len(pending) == 0 {
+		return
+	}
+
+	now := time.TicksAlive()
+	for id, req := range pending {
+		if now < req.deadline {
+			continue
+		}
+
+		delete(pending, id)
+		req.resolve(nil, ErrTimeout)
+	}
+}
+
+func requestMeta(meta Meta) Meta {
+	meta.Identifier += ".request"
+	return meta
+}
+
+func responseMeta(meta Meta) Meta {
+	meta.Identifier += ".response"
+	return meta
+}
+
+// envelope wraps the payload of requests and responses together with the
+// id that correlates them.
+type envelope struct {
+	Id    uint64
+	Value any
+
+	meta Meta
+	raw  []byte
+}
+
+func (e *envelope) Meta() Meta {
+	return e.meta
+}
+
+func (e *envelope) MarshalMsgpack(w *msgpack.Writer) error {
+	if err := w.WriteMapHeader(2); err != nil {
+		return err
+	}
+
+	if err := w.WriteString("id"); err != nil {
+		return err
+	}
+
+	if err := w.WriteUint(e.Id); err != nil {
+		return err
+	}
+
+	if err := w.WriteString("value"); err != nil {
+		return err
+	}
+
+	return w.Encode(e.Value)
+}
+
+func (e *envelope) UnmarshalMsgpack(r *msgpack.Reader) error {
+	h, err := r.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < h; i++ {
+		key, err := r.ReadString()
+		if err != nil {
+			return err
+		}
+
+		switch key {
+		case "id":
+			e.Id, err = r.ReadUint()
+		case "value":
+			e.raw, err = r.ReadRaw()
+		default:
+			err = r.Skip()
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
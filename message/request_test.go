@@ -0,0 +1,120 @@
+package message_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oriolus-software/script-go/message"
+	"github.com/oriolus-software/script-go/scripttest"
+)
+
+type stateRequest struct {
+	Unit int `msgpack:"unit"`
+}
+
+func (stateRequest) Meta() message.Meta {
+	return message.Meta{Namespace: "traction", Identifier: "state"}
+}
+
+type stateResponse struct {
+	Unit  int     `msgpack:"unit"`
+	Force float64 `msgpack:"force"`
+}
+
+func (stateResponse) Meta() message.Meta {
+	return message.Meta{Namespace: "traction", Identifier: "state"}
+}
+
+func TestRequest(t *testing.T) {
+	rt := scripttest.New(t)
+
+	sub := message.RegisterResponder(func(in message.Incoming[stateRequest]) stateResponse {
+		return stateResponse{Unit: in.Payload.Unit, Force: 1200}
+	})
+	t.Cleanup(sub.Unsubscribe)
+
+	var got *stateResponse
+	message.Request(stateRequest{Unit: 2}, message.Myself, func(resp stateResponse, err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = &resp
+	})
+
+	rt.TickN(2)
+
+	if got == nil {
+		t.Fatal("expected a response")
+	}
+
+	if got.Unit != 2 || got.Force != 1200 {
+		t.Fatalf("unexpected response %+v", got)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	rt := scripttest.New(t)
+
+	var err error
+	message.Request(stateRequest{}, message.Parent, func(_ stateResponse, e error) {
+		err = e
+	})
+
+	rt.TickN(int(message.RequestTimeout) - 1)
+	if err != nil {
+		t.Fatalf("expected request to still be pending, got %v", err)
+	}
+
+	rt.Tick()
+	if err != message.ErrTimeout {
+		t.Fatalf("expected timeout, got %v", err)
+	}
+}
+
+// wireRequest is a request as it arrives from another script.
+type wireRequest struct {
+	Id    uint64       `msgpack:"id"`
+	Value stateRequest `msgpack:"value"`
+}
+
+func (wireRequest) Meta() message.Meta {
+	return message.Meta{Namespace: "traction", Identifier: "state.request"}
+}
+
+func TestResponseRouting(t *testing.T) {
+	tests := []struct {
+		name   string
+		slot   int
+		source message.MessageSource
+		want   any
+	}{
+		{"child", -1, message.MessageSource{ModuleSlotIndex: 1, ModuleSlotCockpitIndex: -1}, map[string]any{"ChildByIndex": int64(1)}},
+		{"coupling", -1, message.MessageSource{Coupling: "rear", ModuleSlotIndex: -1, ModuleSlotCockpitIndex: -1}, map[string]any{"AcrossCoupling": map[string]any{"coupling": "rear", "cascade": false}}},
+		{"parent", 2, message.MessageSource{ModuleSlotIndex: -1, ModuleSlotCockpitIndex: -1}, "Parent"},
+		{"self", -1, message.MessageSource{ModuleSlotIndex: -1, ModuleSlotCockpitIndex: -1}, "Myself"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := scripttest.New(t)
+			rt.SetEnv(scripttest.Env{ModuleSlotIndex: tt.slot, ModuleSlotCockpitIndex: -1, ModuleSlotIndexInClassGroup: -1})
+
+			sub := message.RegisterResponder(func(in message.Incoming[stateRequest]) stateResponse {
+				return stateResponse{Unit: in.Payload.Unit}
+			})
+			t.Cleanup(sub.Unsubscribe)
+
+			rt.Deliver(wireRequest{Id: 7, Value: stateRequest{Unit: 3}}, tt.source)
+			rt.Tick()
+
+			sent := rt.Sent()
+			if len(sent) != 1 {
+				t.Fatalf("expected one response, got %d", len(sent))
+			}
+
+			if !reflect.DeepEqual(sent[0].Targets, []any{tt.want}) {
+				t.Fatalf("expected target %v, got %v", tt.want, sent[0].Targets)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package message_test
+
+import (
+	"testing"
+
+	"github.com/oriolus-software/script-go/message"
+	"github.com/oriolus-software/script-go/schema"
+)
+
+// testdata/host.json describes the message envelope as the host encodes it.
+// The source is only received, its int indices hold the host's i32.
+func TestEnvelopeMatchesHost(t *testing.T) {
+	schema.Check(t, "testdata/host.json", message.Meta{})
+	schema.CheckDirection(t, "testdata/host.json", schema.Receive, message.MessageSource{})
+}
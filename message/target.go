@@ -1,10 +1,25 @@
 package message
 
+import (
+	"errors"
+	"fmt"
+
+	"github.com/oriolus-software/script-go/env"
+)
+
 var (
 	Myself Target = myself{}
 	Parent Target = parent{}
+	// Train addresses every script of the whole train consist, including
+	// the sender.
+	Train Target = Broadcast{AcrossCouplings: true, IncludeSelf: true}
 )
 
+// Target is where the host routes a sent message. Only the variants below
+// are known to the host. There is no target for the modules of a class
+// group, env.ModuleSlotIndexInClassGroup is an index within the group and
+// does not name it, and no broadcast filtered by the host. Both need host
+// support before the SDK can offer them.
 type Target interface {
 	toMessageTarget() any
 }
@@ -68,3 +83,53 @@ func (a AcrossCoupling) toMessageTarget() any {
 		AcrossCoupling: a,
 	}
 }
+
+// ModuleSlot addresses the module in the given slot index of the vehicle,
+// see env.ModuleSlotIndex. Modules are the children of the vehicle script,
+// so it only reaches the module when sent from the vehicle script. It
+// panics if index is negative.
+func ModuleSlot(index int) Target {
+	if index < 0 {
+		panic(fmt.Sprintf("message: invalid module slot index %d", index))
+	}
+
+	return ChildByIndex(index)
+}
+
+// ErrUnreachable is returned by ReplyTo when no target reaches the sender.
+var ErrUnreachable = errors.New("sender cannot be reached")
+
+// ReplyTo returns the target that reaches the sender of a message with the
+// given source, as seen from the receiving script. Modules can only reach
+// the vehicle script and themselves, so a module receiving a message from
+// another module gets ErrUnreachable.
+func ReplyTo(source MessageSource) (Target, error) {
+	if source.Coupling != "" {
+		return AcrossCoupling{Coupling: source.Coupling}, nil
+	}
+
+	own, inModule := env.ModuleSlotIndex()
+
+	if source.ModuleSlotIndex >= 0 {
+		switch {
+		case !inModule:
+			return ChildByIndex(source.ModuleSlotIndex), nil
+		case source.ModuleSlotIndex == own:
+			return Myself, nil
+		}
+
+		return nil, ErrUnreachable
+	}
+
+	if source.ModuleSlotCockpitIndex >= 0 {
+		return Cockpit(source.ModuleSlotCockpitIndex), nil
+	}
+
+	// The sender is not in a module slot, so it is the vehicle script. That
+	// is our parent, unless we are the vehicle script ourselves.
+	if inModule {
+		return Parent, nil
+	}
+
+	return Myself, nil
+}
@@ -0,0 +1,119 @@
+package message_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/oriolus-software/script-go/message"
+	"github.com/oriolus-software/script-go/scripttest"
+)
+
+type ping struct{}
+
+func (ping) Meta() message.Meta {
+	return message.Meta{Namespace: "target", Identifier: "ping"}
+}
+
+type pong struct{}
+
+func (pong) Meta() message.Meta {
+	return message.Meta{Namespace: "target", Identifier: "pong"}
+}
+
+func TestReplyTo(t *testing.T) {
+	rt := scripttest.New(t)
+
+	sub := message.RegisterHandler(func(in message.Incoming[ping]) {
+		in.Reply(pong{})
+	})
+	defer sub.Unsubscribe()
+
+	tests := []struct {
+		inModule bool
+		source   message.MessageSource
+		expected any
+	}{
+		{false, message.MessageSource{Coupling: "rear", ModuleSlotIndex: 2, ModuleSlotCockpitIndex: -1},
+			map[string]any{"AcrossCoupling": map[string]any{"coupling": "rear", "cascade": false}}},
+		{false, message.MessageSource{ModuleSlotIndex: 2, ModuleSlotCockpitIndex: -1},
+			map[string]any{"ChildByIndex": int64(2)}},
+		{false, message.MessageSource{ModuleSlotIndex: -1, ModuleSlotCockpitIndex: 1},
+			map[string]any{"Cockpit": int64(1)}},
+		{true, message.MessageSource{ModuleSlotIndex: 0, ModuleSlotCockpitIndex: -1}, "Myself"},
+		{true, message.MessageSource{ModuleSlotIndex: -1, ModuleSlotCockpitIndex: -1}, "Parent"},
+		{false, message.MessageSource{ModuleSlotIndex: -1, ModuleSlotCockpitIndex: -1}, "Myself"},
+	}
+
+	for _, test := range tests {
+		env := scripttest.Env{ModuleSlotIndex: -1, ModuleSlotCockpitIndex: -1, ModuleSlotIndexInClassGroup: -1}
+		if test.inModule {
+			env.ModuleSlotIndex = 0
+		}
+		rt.SetEnv(env)
+		rt.ClearSent()
+
+		rt.Deliver(ping{}, test.source)
+		rt.Tick()
+
+		sent := rt.Sent()
+		if len(sent) != 1 {
+			t.Fatalf("source %+v: expected 1 reply, got %d", test.source, len(sent))
+		}
+
+		if !equal(sent[0].Targets[0], test.expected) {
+			t.Fatalf("source %+v: expected target %v, got %v", test.source, test.expected, sent[0].Targets[0])
+		}
+	}
+}
+
+func TestReplyToSiblingModule(t *testing.T) {
+	rt := scripttest.New(t)
+	rt.SetEnv(scripttest.Env{ModuleSlotIndex: 1, ModuleSlotCockpitIndex: -1, ModuleSlotIndexInClassGroup: -1})
+
+	var err error
+	sub := message.RegisterHandler(func(in message.Incoming[ping]) {
+		err = in.Reply(pong{})
+	})
+	defer sub.Unsubscribe()
+
+	rt.Deliver(ping{}, message.MessageSource{ModuleSlotIndex: 2, ModuleSlotCockpitIndex: -1})
+	rt.Tick()
+
+	if !errors.Is(err, message.ErrUnreachable) {
+		t.Fatalf("got error %v, want ErrUnreachable", err)
+	}
+
+	if sent := rt.Sent(); len(sent) != 0 {
+		t.Fatalf("reply to a sibling module was sent to %v", sent[0].Targets)
+	}
+}
+
+func TestModuleSlotRejectsNegativeIndex(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("negative module slot index did not panic")
+		}
+	}()
+
+	message.ModuleSlot(-1)
+}
+
+func equal(a, b any) bool {
+	am, aok := a.(map[string]any)
+	bm, bok := b.(map[string]any)
+	if !aok || !bok {
+		return a == b
+	}
+
+	if len(am) != len(bm) {
+		return false
+	}
+
+	for k, v := range am {
+		if !equal(v, bm[k]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,96 @@
+package message
+
+import (
+	gotime "time"
+
+	"github.com/oriolus-software/script-go/time"
+)
+
+// Timer is a scheduled send created by SendAfter or SendEvery.
+type Timer struct {
+	message   Message
+	targets   []Target
+	remaining float64
+	interval  float64
+	stopped   bool
+}
+
+var timers []*Timer
+
+// SendAfter sends message to targets once d of game time has passed.
+func SendAfter(d gotime.Duration, message Message, targets ...Target) *Timer {
+	return schedule(&Timer{
+		message:   message,
+		targets:   targets,
+		remaining: d.Seconds(),
+	})
+}
+
+// SendEvery sends message to targets every interval of game time, starting
+// one interval from now. The message is sent as passed, use a pointer to
+// send its current state each time.
+func SendEvery(interval gotime.Duration, message Message, targets ...Target) *Timer {
+	return schedule(&Timer{
+		message:   message,
+		targets:   targets,
+		remaining: interval.Seconds(),
+		interval:  interval.Seconds(),
+	})
+}
+
+// Cancel stops the timer. It does nothing if the timer already fired.
+func (t *Timer) Cancel() {
+	t.stopped = true
+}
+
+// AdvanceTimers advances all timers by the duration of the current tick and
+// sends the messages that are due. The script package calls it every tick.
+func AdvanceTimers() {
+	if len(timers) == 0 {
+		return
+	}
+
+	delta := time.Delta64()
+	rest := timers
+	timers = nil
+
+	var active []*Timer
+	defer func() {
+		// If Send panics, the timers not advanced yet are kept as they are.
+		// Timers scheduled meanwhile run from the next tick on.
+		timers = append(append(active, rest...), timers...)
+	}()
+
+	for len(rest) > 0 {
+		t := rest[0]
+		rest = rest[1:]
+
+		if t.stopped {
+			continue
+		}
+
+		t.remaining -= delta
+		if t.remaining > 0 {
+			active = append(active, t)
+			continue
+		}
+
+		if t.interval > 0 {
+			// Keep the phase, but never send more than once per tick.
+			t.remaining += t.interval
+			if t.remaining <= 0 {
+				t.remaining = t.interval
+			}
+			active = append(active, t)
+		} else {
+			t.stopped = true
+		}
+
+		Send(t.message, t.targets...)
+	}
+}
+
+func schedule(t *Timer) *Timer {
+	timers = append(timers, t)
+	return t
+}
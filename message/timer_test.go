@@ -0,0 +1,97 @@
+package message_test
+
+import (
+	"errors"
+	"testing"
+	gotime "time"
+
+	"github.com/oriolus-software/script-go/internal/msgpack"
+	"github.com/oriolus-software/script-go/message"
+	"github.com/oriolus-software/script-go/scripttest"
+)
+
+type chime struct {
+	Count int `msgpack:"count"`
+}
+
+func (chime) Meta() message.Meta {
+	return message.Meta{Namespace: "doors", Identifier: "chime"}
+}
+
+func TestSendAfter(t *testing.T) {
+	rt := scripttest.New(t)
+	rt.SetDelta(0.5)
+
+	message.SendAfter(1200*gotime.Millisecond, chime{Count: 1}, message.Parent)
+	cancelled := message.SendAfter(gotime.Second, chime{Count: 2}, message.Parent)
+	cancelled.Cancel()
+
+	rt.TickN(2)
+	if sent := scripttest.SentOf[chime](rt); len(sent) != 0 {
+		t.Fatalf("expected nothing after 1s, got %v", sent)
+	}
+
+	rt.TickN(3)
+	sent := scripttest.SentOf[chime](rt)
+	if len(sent) != 1 || sent[0].Count != 1 {
+		t.Fatalf("expected exactly the first chime, got %v", sent)
+	}
+}
+
+func TestSendEvery(t *testing.T) {
+	rt := scripttest.New(t)
+	rt.SetDelta(0.25)
+
+	c := &chime{}
+	timer := message.SendEvery(gotime.Second, c, message.Parent)
+
+	for i := 1; i <= 12; i++ {
+		c.Count = i
+		rt.Tick()
+	}
+
+	sent := scripttest.SentOf[chime](rt)
+	if len(sent) != 3 || sent[0].Count != 4 || sent[2].Count != 12 {
+		t.Fatalf("expected a chime every 4 ticks, got %v", sent)
+	}
+
+	timer.Cancel()
+	rt.TickN(8)
+
+	if sent := scripttest.SentOf[chime](rt); len(sent) != 3 {
+		t.Fatalf("expected no chimes after cancel, got %v", sent)
+	}
+}
+
+type brokenChime struct{}
+
+func (brokenChime) Meta() message.Meta {
+	return message.Meta{Namespace: "doors", Identifier: "broken_chime"}
+}
+
+func (brokenChime) MarshalMsgpack(*msgpack.Writer) error {
+	return errors.New("broken")
+}
+
+func TestTimersSurvivePanickingSend(t *testing.T) {
+	rt := scripttest.New(t)
+	rt.SetDelta(0.5)
+
+	message.SendAfter(gotime.Second, chime{}, message.Parent).Cancel()
+	message.SendEvery(500*gotime.Millisecond, chime{Count: 1}, message.Parent)
+	message.SendAfter(500*gotime.Millisecond, brokenChime{}, message.Parent)
+
+	rt.Tick()
+	if logs := rt.Logs(); len(logs) != 1 {
+		t.Fatalf("expected the panic to be logged once, got %v", logs)
+	}
+
+	rt.TickN(2)
+	if logs := rt.Logs(); len(logs) != 1 {
+		t.Fatalf("expected the broken timer to fire once, got %v", logs)
+	}
+
+	if sent := scripttest.SentOf[chime](rt); len(sent) != 3 {
+		t.Fatalf("expected one chime per tick, got %d", len(sent))
+	}
+}
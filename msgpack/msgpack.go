@@ -0,0 +1,25 @@
+// Package msgpack exposes the SDK's msgpack encoder and decoder, so types
+// outside of this module, such as generated messages, can implement custom
+// encodings.
+package msgpack
+
+import (
+	"github.com/oriolus-software/script-go/internal/msgpack"
+)
+
+type (
+	Writer      = msgpack.Writer
+	Reader      = msgpack.Reader
+	Marshaler   = msgpack.Marshaler
+	Unmarshaler = msgpack.Unmarshaler
+)
+
+var (
+	NewWriter = msgpack.NewWriter
+	NewReader = msgpack.NewReader
+	Marshal   = msgpack.Marshal
+)
+
+func Unmarshal[T any](data []byte, v *T) error {
+	return msgpack.Unmarshal(data, v)
+}
@@ -0,0 +1,23 @@
+//go:build !wasm
+
+package rand
+
+import (
+	"github.com/oriolus-software/script-go/internal/host"
+)
+
+func u64(min, max uint64) uint64 {
+	return host.Current.U64(min, max)
+}
+
+func f64() float64 {
+	return host.Current.F64()
+}
+
+func randomSeed() {
+	host.Current.RandomSeed()
+}
+
+func seed(seed uint64) {
+	host.Current.Seed(seed)
+}
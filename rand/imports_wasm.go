@@ -0,0 +1,19 @@
+//go:build wasm
+
+package rand
+
+//go:wasm-module rand
+//export u64
+func u64(min, max uint64) uint64
+
+//go:wasm-module rand
+//export f64
+func f64() float64
+
+//go:wasm-module rand
+//export random_seed
+func randomSeed()
+
+//go:wasm-module rand
+//export seed
+func seed(seed uint64)
@@ -19,19 +19,3 @@ func RandomSeed() {
 func Seed(s uint64) {
 	seed(s)
 }
-
-//go:wasm-module rand
-//export u64
-func u64(min, max uint64) uint64
-
-//go:wasm-module rand
-//export f64
-func f64() float64
-
-//go:wasm-module rand
-//export random_seed
-func randomSeed()
-
-//go:wasm-module rand
-//export seed
-func seed(seed uint64)
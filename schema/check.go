@@ -0,0 +1,39 @@
+package schema
+
+// TB is the part of testing.TB used by Check.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+}
+
+// Check compares the Go types of values against the schema at path and
+// reports every mismatch as a test error. The types are expected to be sent
+// and received, see CheckDirection.
+//
+//	func TestMessagesMatchRust(t *testing.T) {
+//		schema.Check(t, "testdata/doors.json", DoorState{}, DoorCommand{})
+//	}
+func Check(t TB, path string, values ...any) {
+	t.Helper()
+	CheckDirection(t, path, Both, values...)
+}
+
+// CheckDirection is like Check for types flowing in the given direction.
+func CheckDirection(t TB, path string, direction Direction, values ...any) {
+	t.Helper()
+
+	want, err := Load(path)
+	if err != nil {
+		t.Fatalf("load schema: %v", err)
+	}
+
+	got, err := FromTypes(values...)
+	if err != nil {
+		t.Fatalf("describe Go types: %v", err)
+	}
+
+	for _, m := range CompareDirection(want, got, direction) {
+		t.Errorf("%s", m)
+	}
+}
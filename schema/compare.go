@@ -0,0 +1,285 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Mismatch is an incompatibility between two descriptions of a type.
+type Mismatch struct {
+	// Path names the message or struct, followed by the keys of the fields
+	// leading to the mismatch.
+	Path    string
+	Problem string
+}
+
+func (m Mismatch) String() string {
+	return m.Path + ": " + m.Problem
+}
+
+// Direction is the way values of the compared types flow between the Go
+// script and the script that exported the schema.
+type Direction int
+
+const (
+	// Both is for types that are sent and received. Numbers must have the
+	// same type on both sides.
+	Both Direction = iota
+	// Receive is for types the Go script only decodes. Go numbers must hold
+	// every value of the schema's type, e.g. i64 for i32.
+	Receive
+	// Send is for types the Go script only encodes. The schema's numbers
+	// must hold every value of the Go type.
+	Send
+)
+
+// Compare reports how got differs from want for types flowing both ways,
+// see CompareDirection.
+func Compare(want, got *Schema) []Mismatch {
+	return CompareDirection(want, got, Both)
+}
+
+// CompareDirection reports how got differs from want. want is usually the
+// schema exported by another script and got the schema of the Go types.
+//
+// Messages are matched by namespace and identifier, structs that are not
+// referenced by a message by name. Fields are matched by key and their
+// struct types are compared structurally, so the names of nested structs
+// may differ. Integer and float fields of different types are only
+// compatible if values flow in one direction only and the receiving side
+// can hold every value of the sending side.
+func CompareDirection(want, got *Schema, direction Direction) []Mismatch {
+	c := &comparer{want: want, got: got, direction: direction, seen: make(map[[2]string]bool)}
+
+	for _, gm := range got.Messages {
+		wm, ok := want.message(gm.Namespace, gm.Identifier)
+		if !ok {
+			c.report(gm.Name, "message %s.%s is not in the schema", gm.Namespace, gm.Identifier)
+			continue
+		}
+
+		if gm.Bus != wm.Bus {
+			c.report(gm.Name, "bus is %q, schema has %q", gm.Bus, wm.Bus)
+		}
+
+		c.seen[[2]string{wm.Name, gm.Name}] = true
+		c.fields(gm.Name, wm.Fields, gm.Fields)
+	}
+
+	referenced := got.referenced()
+	for _, gs := range got.Structs {
+		// Structs referenced by fields are compared with their parents.
+		if c.compared(gs.Name) || referenced[gs.Name] {
+			continue
+		}
+
+		ws, ok := want.fields(gs.Name)
+		if !ok {
+			c.report(gs.Name, "struct %s is not in the schema", gs.Name)
+			continue
+		}
+
+		c.seen[[2]string{gs.Name, gs.Name}] = true
+		c.fields(gs.Name, ws, gs.Fields)
+	}
+
+	return c.mismatches
+}
+
+type comparer struct {
+	want, got  *Schema
+	direction  Direction
+	seen       map[[2]string]bool
+	mismatches []Mismatch
+}
+
+func (c *comparer) report(path, format string, args ...any) {
+	c.mismatches = append(c.mismatches, Mismatch{Path: path, Problem: fmt.Sprintf(format, args...)})
+}
+
+// compared reports whether the got type name was already compared.
+func (c *comparer) compared(name string) bool {
+	for pair := range c.seen {
+		if pair[1] == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *comparer) fields(path string, want, got []Field) {
+	for _, wf := range want {
+		gf, ok := findField(got, wf.Key)
+		if !ok {
+			c.report(path, "field %s is missing", wf.Key)
+			continue
+		}
+
+		fieldPath := path + "." + wf.Key
+
+		if wf.Optional != gf.Optional {
+			if wf.Optional {
+				c.report(fieldPath, "optional in the schema but required")
+			} else {
+				c.report(fieldPath, "required in the schema but optional")
+			}
+		}
+
+		wt, werr := ParseType(wf.Type)
+		gt, gerr := ParseType(gf.Type)
+		if werr != nil || gerr != nil {
+			c.report(fieldPath, "cannot compare types %q and %q", wf.Type, gf.Type)
+			continue
+		}
+
+		c.types(fieldPath, wt, gt)
+	}
+
+	for _, gf := range got {
+		if _, ok := findField(want, gf.Key); !ok {
+			c.report(path, "field %s is not in the schema", gf.Key)
+		}
+	}
+}
+
+func (c *comparer) types(path string, want, got Type) {
+	switch {
+	case want.Kind == "list" && got.Kind == "list":
+		c.types(path+"[]", *want.Elem, *got.Elem)
+	case want.Kind == "struct" && got.Kind == "struct":
+		pair := [2]string{want.Name, got.Name}
+		if c.seen[pair] {
+			return
+		}
+		c.seen[pair] = true
+
+		wf, wok := c.want.fields(want.Name)
+		gf, gok := c.got.fields(got.Name)
+		if !wok || !gok {
+			c.report(path, "cannot resolve %s and %s", want.Name, got.Name)
+			return
+		}
+
+		c.fields(path, wf, gf)
+	case !c.compatible(got.Kind, want.Kind):
+		c.report(path, "type is %s, schema has %s", got, want)
+	}
+}
+
+func (c *comparer) compatible(got, want string) bool {
+	switch c.direction {
+	case Receive:
+		return holds(got, want)
+	case Send:
+		return holds(want, got)
+	}
+
+	return got == want
+}
+
+var numbers = map[string]struct {
+	kind byte
+	bits int
+}{
+	"i8": {'i', 8}, "i16": {'i', 16}, "i32": {'i', 32}, "i64": {'i', 64},
+	"u8": {'u', 8}, "u16": {'u', 16}, "u32": {'u', 32}, "u64": {'u', 64},
+	"f32": {'f', 32}, "f64": {'f', 64},
+}
+
+// holds reports whether a value of kind to can hold every value of kind
+// from.
+func holds(to, from string) bool {
+	if to == from {
+		return true
+	}
+
+	g, gok := numbers[to]
+	w, wok := numbers[from]
+	if !gok || !wok {
+		return false
+	}
+
+	switch {
+	case g.kind == w.kind:
+		return g.bits >= w.bits
+	case g.kind == 'i' && w.kind == 'u':
+		return g.bits > w.bits
+	}
+
+	return false
+}
+
+func (s *Schema) message(namespace, identifier string) (Message, bool) {
+	for _, m := range s.Messages {
+		if m.Namespace == namespace && m.Identifier == identifier {
+			return m, true
+		}
+	}
+
+	return Message{}, false
+}
+
+// fields returns the fields of the message or struct called name.
+func (s *Schema) fields(name string) ([]Field, bool) {
+	for _, m := range s.Messages {
+		if m.Name == name {
+			return m.Fields, true
+		}
+	}
+
+	for _, st := range s.Structs {
+		if st.Name == name {
+			return st.Fields, true
+		}
+	}
+
+	return nil, false
+}
+
+// referenced returns the names of all types used by fields of s.
+func (s *Schema) referenced() map[string]bool {
+	names := make(map[string]bool)
+	add := func(fields []Field) {
+		for _, f := range fields {
+			t, err := ParseType(f.Type)
+			for err == nil && t.Kind == "list" {
+				t = *t.Elem
+			}
+
+			if err == nil && t.Kind == "struct" {
+				names[t.Name] = true
+			}
+		}
+	}
+
+	for _, m := range s.Messages {
+		add(m.Fields)
+	}
+
+	for _, st := range s.Structs {
+		add(st.Fields)
+	}
+
+	return names
+}
+
+func findField(fields []Field, key string) (Field, bool) {
+	for _, f := range fields {
+		if f.Key == key {
+			return f, true
+		}
+	}
+
+	return Field{}, false
+}
+
+// Format returns the mismatches one per line.
+func Format(mismatches []Mismatch) string {
+	lines := make([]string, len(mismatches))
+	for i, m := range mismatches {
+		lines[i] = m.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,166 @@
+package schema_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oriolus-software/script-go/message"
+	"github.com/oriolus-software/script-go/msgpack"
+	"github.com/oriolus-software/script-go/schema"
+)
+
+type wheel struct {
+	Slip float32 `msgpack:"slip"`
+}
+
+type axleState struct {
+	Speed  float64  `msgpack:"speed"`
+	Count  uint8    `msgpack:"count"`
+	Offset int16    `msgpack:"offset"`
+	Label  *string  `msgpack:"label"`
+	Wheels []wheel  `msgpack:"wheels"`
+	Extra  []string `msgpack:"extra"`
+	cache  int
+}
+
+func (axleState) Meta() message.Meta {
+	return message.Meta{Namespace: "bogie", Identifier: "axle", Bus: "bogie"}
+}
+
+// rustSchema is what the other side exports for the axle message.
+const rustSchema = `{
+	"messages": [{
+		"name": "AxleState", "namespace": "bogie", "identifier": "axle", "bus": "bogie",
+		"fields": [
+			{"key": "speed", "type": "f32"},
+			{"key": "count", "type": "u16"},
+			{"key": "offset", "type": "u8"},
+			{"key": "label", "type": "string"},
+			{"key": "wheels", "type": "list<WheelState>"},
+			{"key": "load", "type": "f32", "optional": true}
+		]
+	}],
+	"structs": [{
+		"name": "WheelState",
+		"fields": [{"key": "slip", "type": "f64"}]
+	}]
+}`
+
+func TestFromTypes(t *testing.T) {
+	s, err := schema.FromTypes(axleState{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &schema.Schema{
+		Messages: []schema.Message{{
+			Name: "axleState", Namespace: "bogie", Identifier: "axle", Bus: "bogie",
+			Fields: []schema.Field{
+				{Key: "speed", Name: "Speed", Type: "f64"},
+				{Key: "count", Name: "Count", Type: "u8"},
+				{Key: "offset", Name: "Offset", Type: "i16"},
+				{Key: "label", Name: "Label", Type: "string", Optional: true},
+				{Key: "wheels", Name: "Wheels", Type: "list<wheel>"},
+				{Key: "extra", Name: "Extra", Type: "list<string>"},
+			},
+		}},
+		Structs: []schema.Struct{{
+			Name:   "wheel",
+			Fields: []schema.Field{{Key: "slip", Name: "Slip", Type: "f32"}},
+		}},
+	}
+
+	if !reflect.DeepEqual(s, want) {
+		t.Fatalf("got %+v, want %+v", s, want)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	want, err := schema.Parse([]byte(rustSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := schema.FromTypes(axleState{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// wheel is compared with WheelState field by field. Numbers have to
+	// match exactly unless the values only flow one way.
+	tests := []struct {
+		direction schema.Direction
+		expected  []string
+	}{
+		{schema.Both, []string{
+			"axleState.speed: type is f64, schema has f32",
+			"axleState.count: type is u8, schema has u16",
+			"axleState.offset: type is i16, schema has u8",
+			"axleState.label: required in the schema but optional",
+			"axleState.wheels[].slip: type is f32, schema has f64",
+			"axleState: field load is missing",
+			"axleState: field extra is not in the schema",
+		}},
+		// Received, f64 holds every f32 and i16 every u8.
+		{schema.Receive, []string{
+			"axleState.count: type is u8, schema has u16",
+			"axleState.label: required in the schema but optional",
+			"axleState.wheels[].slip: type is f32, schema has f64",
+			"axleState: field load is missing",
+			"axleState: field extra is not in the schema",
+		}},
+		{schema.Send, []string{
+			"axleState.speed: type is f64, schema has f32",
+			"axleState.offset: type is i16, schema has u8",
+			"axleState.label: required in the schema but optional",
+			"axleState: field load is missing",
+			"axleState: field extra is not in the schema",
+		}},
+	}
+
+	for _, test := range tests {
+		mismatches := schema.CompareDirection(want, got, test.direction)
+
+		var lines []string
+		for _, m := range mismatches {
+			lines = append(lines, m.String())
+		}
+
+		if !reflect.DeepEqual(lines, test.expected) {
+			t.Errorf("direction %d: got mismatches\n%s", test.direction, schema.Format(mismatches))
+		}
+	}
+}
+
+func TestParseMsgpack(t *testing.T) {
+	want, err := schema.Parse([]byte(rustSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := msgpack.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := schema.Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestCompareUnknownMessage(t *testing.T) {
+	got, err := schema.FromTypes(axleState{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mismatches := schema.Compare(&schema.Schema{}, got)
+	if len(mismatches) != 1 || mismatches[0].String() != "axleState: message bogie.axle is not in the schema" {
+		t.Fatalf("got %v", mismatches)
+	}
+}
@@ -0,0 +1,110 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// GoType is a Go type as far as the mapping to schema types is concerned.
+// FromTypes implements it over reflect, cmd/schemacheck over go/types, so
+// both describe Go types the same way.
+type GoType interface {
+	// Kind is the name of the underlying basic type, like "int8" or
+	// "string", or one of "pointer", "slice", "array" and "struct".
+	Kind() string
+	// Elem is the element type of pointers, slices and arrays.
+	Elem() GoType
+	// Name is the name of a struct type, empty for anonymous structs.
+	Name() string
+	// NumField and Field list the fields of a struct type.
+	NumField() int
+	Field(i int) GoField
+	String() string
+}
+
+type GoField struct {
+	Name     string
+	Exported bool
+	Tag      reflect.StructTag
+	Type     GoType
+}
+
+var basicTypes = map[string]string{
+	"bool": "bool", "string": "string",
+	"int": "i64", "int8": "i8", "int16": "i16", "int32": "i32", "int64": "i64",
+	"uint": "u64", "uint8": "u8", "uint16": "u16", "uint32": "u32", "uint64": "u64",
+	"float32": "f32", "float64": "f64",
+}
+
+// FieldsOf describes the fields of the struct type t the way the SDK's
+// msgpack encoder sees them. declare is called for every struct type the
+// fields refer to.
+//
+// Pointer fields are optional, as are slice fields tagged
+// `schema:"optional"`. int and uint are described as i64 and u64.
+func FieldsOf(t GoType, declare func(GoType) error) ([]Field, error) {
+	var fields []Field
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.Exported {
+			continue
+		}
+
+		// Mirrors the key selection of the msgpack encoder.
+		key := field.Name
+		if tag := field.Tag.Get("msgpack"); tag != "" && tag != "-" {
+			key = tag
+		}
+
+		ft := field.Type
+		optional := field.Tag.Get("schema") == "optional"
+		for ft.Kind() == "pointer" {
+			ft = ft.Elem()
+			optional = true
+		}
+
+		typ, err := typeOf(ft, declare)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+
+		fields = append(fields, Field{Key: key, Name: field.Name, Type: typ, Optional: optional})
+	}
+
+	return fields, nil
+}
+
+func typeOf(t GoType, declare func(GoType) error) (string, error) {
+	switch kind := t.Kind(); kind {
+	case "pointer":
+		return typeOf(t.Elem(), declare)
+	case "slice", "array":
+		if kind == "slice" && t.Elem().Kind() == "uint8" {
+			return "bytes", nil
+		}
+
+		elem, err := typeOf(t.Elem(), declare)
+		if err != nil {
+			return "", err
+		}
+
+		return "list<" + elem + ">", nil
+	case "struct":
+		if t.Name() == "" {
+			return "", fmt.Errorf("anonymous struct %s is not supported", t)
+		}
+
+		if err := declare(t); err != nil {
+			return "", err
+		}
+
+		return t.Name(), nil
+	default:
+		if name, ok := basicTypes[kind]; ok {
+			return name, nil
+		}
+
+		return "", fmt.Errorf("unsupported type %s", t)
+	}
+}
@@ -0,0 +1,123 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/oriolus-software/script-go/message"
+)
+
+// FromTypes describes Go types the way the SDK's msgpack encoder sees them.
+// Values implementing message.Message become messages, all other values and
+// every struct they reference become structs.
+//
+// Fields are described by FieldsOf. Custom MarshalMsgpack implementations
+// are not inspected, the exported fields of such types are described as if
+// the reflective encoder was used.
+func FromTypes(values ...any) (*Schema, error) {
+	b := &builder{
+		schema: &Schema{},
+		types:  make(map[string]reflect.Type),
+	}
+
+	for _, v := range values {
+		rt := reflect.TypeOf(v)
+		for rt != nil && rt.Kind() == reflect.Ptr {
+			rt = rt.Elem()
+		}
+
+		if rt == nil || rt.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%T is not a struct", v)
+		}
+
+		if err := b.declare(rt); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.schema, nil
+}
+
+type builder struct {
+	schema *Schema
+	types  map[string]reflect.Type
+}
+
+var messageType = reflect.TypeOf((*message.Message)(nil)).Elem()
+
+func (b *builder) declare(rt reflect.Type) error {
+	name := rt.Name()
+	if name == "" {
+		return fmt.Errorf("anonymous struct %s is not supported", rt)
+	}
+
+	if prev, ok := b.types[name]; ok {
+		if prev != rt {
+			return fmt.Errorf("type %s is declared by %s and %s", name, prev.PkgPath(), rt.PkgPath())
+		}
+
+		return nil
+	}
+	b.types[name] = rt
+
+	fields, err := FieldsOf(reflectType{rt}, func(t GoType) error {
+		return b.declare(t.(reflectType).t)
+	})
+	if err != nil {
+		return err
+	}
+
+	if rt.Implements(messageType) {
+		meta := reflect.Zero(rt).Interface().(message.Message).Meta()
+		b.schema.Messages = append(b.schema.Messages, Message{
+			Name:       name,
+			Namespace:  meta.Namespace,
+			Identifier: meta.Identifier,
+			Bus:        meta.Bus,
+			Fields:     fields,
+		})
+	} else {
+		b.schema.Structs = append(b.schema.Structs, Struct{Name: name, Fields: fields})
+	}
+
+	return nil
+}
+
+// reflectType implements GoType for reflect types.
+type reflectType struct {
+	t reflect.Type
+}
+
+func (r reflectType) Kind() string {
+	if r.t.Kind() == reflect.Ptr {
+		return "pointer"
+	}
+
+	return r.t.Kind().String()
+}
+
+func (r reflectType) Elem() GoType {
+	return reflectType{r.t.Elem()}
+}
+
+func (r reflectType) Name() string {
+	return r.t.Name()
+}
+
+func (r reflectType) NumField() int {
+	return r.t.NumField()
+}
+
+func (r reflectType) Field(i int) GoField {
+	field := r.t.Field(i)
+	return GoField{
+		Name:     field.Name,
+		Exported: field.IsExported(),
+		Tag:      field.Tag,
+		Type:     reflectType{field.Type},
+	}
+}
+
+func (r reflectType) String() string {
+	return r.t.String()
+}
@@ -0,0 +1,237 @@
+// Package schema describes message types independently of the language that
+// implements them. Schemas are used to generate Go message types and to
+// check Go types against the message definitions of other scripts.
+//
+// Field types are one of bool, string, bytes, i8, i16, i32, i64, u8, u16,
+// u32, u64, f32, f64, list<T> or the name of a struct or message declared in
+// the same schema.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/oriolus-software/script-go/internal/msgpack"
+)
+
+type Schema struct {
+	Messages []Message `json:"messages" msgpack:"messages"`
+	Structs  []Struct  `json:"structs" msgpack:"structs"`
+}
+
+type Message struct {
+	Name       string  `json:"name" msgpack:"name"`
+	Namespace  string  `json:"namespace" msgpack:"namespace"`
+	Identifier string  `json:"identifier" msgpack:"identifier"`
+	Bus        string  `json:"bus" msgpack:"bus"`
+	Fields     []Field `json:"fields" msgpack:"fields"`
+}
+
+// Struct is a type used by message fields that is not a message itself.
+type Struct struct {
+	Name   string  `json:"name" msgpack:"name"`
+	Fields []Field `json:"fields" msgpack:"fields"`
+}
+
+type Field struct {
+	// Key is the name of the field in the encoded message.
+	Key string `json:"key" msgpack:"key"`
+	// Name is the Go name of the field. It defaults to Key in camel case.
+	Name     string `json:"name" msgpack:"name"`
+	Type     string `json:"type" msgpack:"type"`
+	Optional bool   `json:"optional" msgpack:"optional"`
+}
+
+// Type is a parsed field type.
+type Type struct {
+	// Kind is a primitive type name, "list" or "struct".
+	Kind string
+	// Elem is the element type of a list.
+	Elem *Type
+	// Name is the name of a struct or message.
+	Name string
+}
+
+var primitives = map[string]bool{
+	"bool": true, "string": true, "bytes": true,
+	"i8": true, "i16": true, "i32": true, "i64": true,
+	"u8": true, "u16": true, "u32": true, "u64": true,
+	"f32": true, "f64": true,
+}
+
+// Load reads and validates a schema file.
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(data)
+}
+
+// Parse decodes and validates a schema. JSON objects are decoded as JSON,
+// anything else as msgpack.
+func Parse(data []byte) (*Schema, error) {
+	var s Schema
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+	} else if err := msgpack.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// Validate checks that names are unique and every field type is known.
+func (s *Schema) Validate() error {
+	names := make(map[string]bool)
+	for _, name := range s.typeNames() {
+		if names[name] {
+			return fmt.Errorf("type %s is declared twice", name)
+		}
+		names[name] = true
+	}
+
+	// Generated types have these methods, so fields cannot use their names.
+	reserved := []string{"MarshalMsgpack", "UnmarshalMsgpack"}
+
+	check := func(owner string, fields []Field, reserved []string) error {
+		keys := make(map[string]bool)
+		goNames := make(map[string]bool)
+		for _, f := range fields {
+			if f.Key == "" {
+				return fmt.Errorf("%s: field without key", owner)
+			}
+
+			if keys[f.Key] {
+				return fmt.Errorf("%s: field %s is declared twice", owner, f.Key)
+			}
+			keys[f.Key] = true
+
+			name := f.GoName()
+			if slices.Contains(reserved, name) {
+				return fmt.Errorf("%s.%s: Go name %s clashes with the %s method", owner, f.Key, name, name)
+			}
+
+			if goNames[name] {
+				return fmt.Errorf("%s.%s: Go name %s is used by another field", owner, f.Key, name)
+			}
+			goNames[name] = true
+
+			t, err := ParseType(f.Type)
+			if err != nil {
+				return fmt.Errorf("%s.%s: %w", owner, f.Key, err)
+			}
+
+			for t.Kind == "list" {
+				t = *t.Elem
+			}
+
+			if t.Kind == "struct" && !names[t.Name] {
+				return fmt.Errorf("%s.%s: unknown type %s", owner, f.Key, t.Name)
+			}
+		}
+
+		return nil
+	}
+
+	for _, m := range s.Messages {
+		if m.Namespace == "" || m.Identifier == "" {
+			return fmt.Errorf("message %s needs a namespace and an identifier", m.Name)
+		}
+
+		if err := check(m.Name, m.Fields, append(reserved, "Meta")); err != nil {
+			return err
+		}
+	}
+
+	for _, st := range s.Structs {
+		if err := check(st.Name, st.Fields, reserved); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) typeNames() []string {
+	var names []string
+	for _, m := range s.Messages {
+		names = append(names, m.Name)
+	}
+
+	for _, st := range s.Structs {
+		names = append(names, st.Name)
+	}
+
+	return names
+}
+
+// GoName returns the Go name of the field.
+func (f Field) GoName() string {
+	if f.Name != "" {
+		return f.Name
+	}
+
+	return CamelCase(f.Key)
+}
+
+// CamelCase turns a snake_case key into an exported Go name.
+func CamelCase(key string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(key, "_") {
+		if part == "" {
+			continue
+		}
+
+		b.WriteString(strings.ToUpper(part[:1]))
+		b.WriteString(part[1:])
+	}
+
+	return b.String()
+}
+
+// ParseType parses a field type.
+func ParseType(s string) (Type, error) {
+	s = strings.TrimSpace(s)
+
+	if primitives[s] {
+		return Type{Kind: s}, nil
+	}
+
+	if strings.HasPrefix(s, "list<") && strings.HasSuffix(s, ">") {
+		elem, err := ParseType(s[len("list<") : len(s)-1])
+		if err != nil {
+			return Type{}, err
+		}
+
+		return Type{Kind: "list", Elem: &elem}, nil
+	}
+
+	if s == "" || strings.ContainsAny(s, "<> ") {
+		return Type{}, fmt.Errorf("invalid type %q", s)
+	}
+
+	return Type{Kind: "struct", Name: s}, nil
+}
+
+func (t Type) String() string {
+	switch t.Kind {
+	case "list":
+		return "list<" + t.Elem.String() + ">"
+	case "struct":
+		return t.Name
+	}
+
+	return t.Kind
+}
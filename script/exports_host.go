@@ -0,0 +1,13 @@
+//go:build !wasm
+
+package script
+
+import (
+	"github.com/oriolus-software/script-go/internal/host"
+)
+
+func init() {
+	host.Export("init", initScript)
+	host.Export("tick", tick)
+	host.Export("dispose", dispose)
+}
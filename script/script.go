@@ -0,0 +1,136 @@
+// Package script owns the init, tick and dispose exports the host calls into
+// and runs a Script through its lifecycle.
+//
+// A script registers its implementation once, typically from an init
+// function. Input actions are registered with the host before Init runs, so
+// they are declared there as well:
+//
+//	func init() {
+//		input.RegisterAction("horn", "KeyH")
+//		script.Register(&Cockpit{})
+//	}
+//
+// Every export pulls bound variables before calling into the script and
+// flushes variables and textures afterwards. Scheduled messages are sent at
+// the start of tick, received messages are dispatched to their handlers in
+// late_tick, right before LateTick is called. Deferred outgoing messages are
+// flushed at the end of late_tick.
+//
+// The late_tick and register_actions exports belong to the message and input
+// packages, so modules written before this package existed keep working.
+// Linking this package makes them run the lifecycle described above.
+package script
+
+import (
+	"github.com/oriolus-software/script-go/input"
+	"github.com/oriolus-software/script-go/internal/exports"
+	"github.com/oriolus-software/script-go/log"
+	"github.com/oriolus-software/script-go/message"
+	"github.com/oriolus-software/script-go/texture"
+	"github.com/oriolus-software/script-go/vars"
+)
+
+type Script interface {
+	// Init is called once after the script was loaded.
+	Init()
+	// Tick is called every frame.
+	Tick()
+	// LateTick is called every frame after all scripts ticked and after the
+	// messages received this frame were dispatched.
+	LateTick()
+	// Dispose is called before the script is unloaded.
+	Dispose()
+}
+
+// Base implements every method of Script as a no-op, so scripts can embed it
+// and only implement the methods they need.
+type Base struct{}
+
+func (Base) Init()     {}
+func (Base) Tick()     {}
+func (Base) LateTick() {}
+func (Base) Dispose()  {}
+
+var current Script
+
+func init() {
+	exports.LateTick = lateTick
+	exports.RegisterActions = registerActions
+}
+
+// Register sets the script the exports call into, replacing any previously
+// registered one.
+func Register(s Script) {
+	current = s
+}
+
+// registerActions runs from the register_actions export of the input
+// package.
+func registerActions() {
+	run("register_actions", input.FlushActions)
+}
+
+//export init
+func initScript() {
+	run("init", func() {
+		vars.Pull()
+		if current != nil {
+			current.Init()
+		}
+	})
+	flush()
+}
+
+//export tick
+func tick() {
+	run("tick", func() {
+		vars.Pull()
+		message.AdvanceTimers()
+		if current != nil {
+			current.Tick()
+		}
+	})
+	flush()
+}
+
+// lateTick runs from the late_tick export of the message package.
+func lateTick() {
+	run("late_tick", func() {
+		message.Dispatch()
+		if current != nil {
+			current.LateTick()
+		}
+	})
+	flush()
+	run("flush", message.FlushDeferred)
+}
+
+//export dispose
+func dispose() {
+	run("dispose", func() {
+		if current != nil {
+			current.Dispose()
+		}
+	})
+	flush()
+	run("flush", message.FlushDeferred)
+}
+
+func flush() {
+	run("flush", func() {
+		vars.Flush()
+		texture.Flush()
+	})
+}
+
+// run calls fn and reports a panic through the log instead of letting it
+// abort the host call.
+func run(stage string, fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("script panicked in %s: %v", stage, r)
+		}
+	}()
+
+	fn()
+}
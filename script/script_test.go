@@ -0,0 +1,99 @@
+package script_test
+
+import (
+	"testing"
+
+	"github.com/oriolus-software/script-go/input"
+	"github.com/oriolus-software/script-go/internal/exports"
+	"github.com/oriolus-software/script-go/lmath"
+	"github.com/oriolus-software/script-go/script"
+	"github.com/oriolus-software/script-go/scripttest"
+	"github.com/oriolus-software/script-go/texture"
+	"github.com/oriolus-software/script-go/vars"
+)
+
+type display struct {
+	script.Base
+
+	Speed float64 `var:"speed"`
+	Shown float64 `var:"shown_speed"`
+
+	tex       texture.Texture
+	lateTicks int
+}
+
+func (d *display) Init() {
+	vars.Bind(d)
+	d.tex = texture.Create(texture.CreationOptions{Width: 2, Height: 2})
+}
+
+func (d *display) Tick() {
+	d.Shown = d.Speed
+	d.tex.DrawRect(lmath.UVec2{}, lmath.UVec2{X: 2, Y: 2}, texture.Color{G: 255, A: 255})
+
+	if d.Speed < 0 {
+		panic("negative speed")
+	}
+}
+
+func (d *display) LateTick() {
+	d.lateTicks++
+}
+
+func TestLifecycle(t *testing.T) {
+	rt := scripttest.New(t)
+	rt.SetVar("speed", 42.0)
+
+	// Actions are registered before Init, so they are declared up front.
+	input.RegisterAction("horn", "KeyH")
+
+	d := &display{}
+	rt.Load(d)
+
+	if got := rt.RegisteredActions(); len(got) != 1 || got[0] != "horn" {
+		t.Fatalf("expected horn action to be registered, got %v", got)
+	}
+
+	rt.Tick()
+
+	if got := rt.Var("shown_speed"); got != 42.0 {
+		t.Fatalf("expected bound variable to be flushed, got %v", got)
+	}
+
+	if got := rt.Texture(d.tex).Pixel(1, 1); got.G != 255 {
+		t.Fatalf("expected texture to be flushed, got %v", got)
+	}
+
+	if d.lateTicks != 1 {
+		t.Fatalf("expected 1 late tick, got %d", d.lateTicks)
+	}
+
+	rt.SetVar("speed", -1.0)
+	rt.Tick()
+
+	logs := rt.Logs()
+	if len(logs) != 1 || logs[0].Message != "script panicked in tick: negative speed" {
+		t.Fatalf("expected panic to be logged, got %v", logs)
+	}
+
+	if d.lateTicks != 2 {
+		t.Fatalf("expected late tick to run after a panicking tick, got %d", d.lateTicks)
+	}
+}
+
+func TestActionsWithoutScriptPackage(t *testing.T) {
+	rt := scripttest.New(t)
+
+	// Modules not linking the script package register actions from the
+	// register_actions export of the input package.
+	registerActions := exports.RegisterActions
+	exports.RegisterActions = nil
+	t.Cleanup(func() { exports.RegisterActions = registerActions })
+
+	input.RegisterAction("horn", "KeyH")
+	rt.Init()
+
+	if got := rt.RegisteredActions(); len(got) != 1 || got[0] != "horn" {
+		t.Fatalf("expected horn action to be registered, got %v", got)
+	}
+}
@@ -0,0 +1,334 @@
+//go:build !wasm
+
+// Package scripttest runs scripts against the simulated host of the native
+// build, so they can be unit-tested with `go test` instead of being loaded
+// into the simulator.
+//
+// A test creates a Runtime, arranges host state (variables, environment,
+// input, incoming messages), drives ticks and then asserts on what the
+// script wrote back:
+//
+//	rt := scripttest.New(t)
+//	rt.SetVar("throttle", 0.5)
+//	rt.Tick()
+//	if got := rt.Var("traction"); got != 0.5 { ... }
+package scripttest
+
+import (
+	"testing"
+	gotime "time"
+
+	"github.com/oriolus-software/script-go/assets"
+	"github.com/oriolus-software/script-go/font"
+	"github.com/oriolus-software/script-go/input"
+	"github.com/oriolus-software/script-go/internal/host"
+	"github.com/oriolus-software/script-go/internal/msgpack"
+	"github.com/oriolus-software/script-go/lmath"
+	"github.com/oriolus-software/script-go/message"
+	"github.com/oriolus-software/script-go/script"
+	"github.com/oriolus-software/script-go/texture"
+)
+
+type (
+	Env        = host.Env
+	LogEntry   = host.LogEntry
+	Vehicle    = host.Vehicle
+	Bogie      = host.Bogie
+	Axle       = host.Axle
+	Pantograph = host.Pantograph
+	Canvas     = host.Canvas
+)
+
+type Runtime struct {
+	t testing.TB
+}
+
+// New resets the simulated host and returns a runtime driving it. Resetting
+// also drops the state the SDK packages keep for a script, like message
+// handlers, middlewares, timers, deferred sending and variable handles, so tests do
+// not see what earlier tests registered. Everything is reset again when the
+// test finishes.
+func New(t testing.TB) *Runtime {
+	host.Reset()
+	t.Cleanup(host.Reset)
+
+	return &Runtime{t: t}
+}
+
+// Export registers fn under the name of a script export, for scripts that
+// declare their own `//export` functions. The previous export is restored
+// when the test finishes.
+func (rt *Runtime) Export(name string, fn func()) {
+	previous := host.Export(name, fn)
+	rt.t.Cleanup(func() {
+		host.Export(name, previous)
+	})
+}
+
+// Load registers s with the script package and initializes it. The script
+// is unregistered when the test finishes.
+func (rt *Runtime) Load(s script.Script) {
+	script.Register(s)
+	rt.t.Cleanup(func() {
+		script.Register(nil)
+	})
+
+	rt.Init()
+}
+
+// Init runs the exports the host calls once when the script is loaded.
+func (rt *Runtime) Init() {
+	host.Call("register_actions")
+	host.Call("init")
+}
+
+// Dispose runs the export the host calls before the script is unloaded.
+func (rt *Runtime) Dispose() {
+	host.Call("dispose")
+}
+
+// Tick advances the clock by one tick and runs the tick exports in host
+// order.
+func (rt *Runtime) Tick() {
+	h := host.Current
+	h.Time.Ticks++
+	h.Time.GameTime += int64(h.Time.Delta * 1e6)
+
+	host.Call("tick")
+	host.Call("late_tick")
+}
+
+func (rt *Runtime) TickN(n int) {
+	for i := 0; i < n; i++ {
+		rt.Tick()
+	}
+}
+
+// Advance ticks until at least d of game time has passed.
+func (rt *Runtime) Advance(d gotime.Duration) {
+	end := host.Current.Time.GameTime + d.Microseconds()
+	for host.Current.Time.GameTime < end {
+		rt.Tick()
+	}
+}
+
+// SetDelta sets the duration of every following tick in seconds.
+func (rt *Runtime) SetDelta(seconds float64) {
+	host.Current.Time.Delta = seconds
+}
+
+func (rt *Runtime) SetGameTime(t gotime.Time) {
+	host.Current.Time.GameTime = t.UnixMicro()
+}
+
+// SetVar sets a host variable. It accepts the same types as vars.Set.
+func (rt *Runtime) SetVar(name string, value any) {
+	rt.t.Helper()
+
+	h := host.Current
+	switch value := value.(type) {
+	case int:
+		h.SetI64(name, int64(value))
+	case int8:
+		h.SetI64(name, int64(value))
+	case int16:
+		h.SetI64(name, int64(value))
+	case int32:
+		h.SetI64(name, int64(value))
+	case int64:
+		h.SetI64(name, value)
+	case uint:
+		h.SetI64(name, int64(value))
+	case uint8:
+		h.SetI64(name, int64(value))
+	case uint16:
+		h.SetI64(name, int64(value))
+	case uint32:
+		h.SetI64(name, int64(value))
+	case uint64:
+		h.SetI64(name, int64(value))
+	case float32:
+		h.SetF64(name, float64(value))
+	case float64:
+		h.SetF64(name, value)
+	case bool:
+		h.SetBool(name, value)
+	case string:
+		h.SetString(name, value)
+	case assets.ContentId:
+		h.SetContentId(name, host.ContentId(value))
+	default:
+		rt.t.Fatalf("unsupported variable type: %T", value)
+	}
+}
+
+// Var returns the value of a host variable as int64, float64, bool, string
+// or assets.ContentId, or nil if it was never set.
+func (rt *Runtime) Var(name string) any {
+	value := host.Current.Vars[name]
+	if id, ok := value.(host.ContentId); ok {
+		return assets.ContentId(id)
+	}
+
+	return value
+}
+
+func (rt *Runtime) SetEnv(env Env) {
+	host.Current.Env = env
+}
+
+// Vehicle returns the simulated vehicle. Its fields can be changed to
+// arrange inputs and read to check the forces a script applied.
+func (rt *Runtime) Vehicle() *Vehicle {
+	return &host.Current.Vehicle
+}
+
+func (rt *Runtime) Logs() []LogEntry {
+	return host.Current.Logs
+}
+
+func (rt *Runtime) Preloads() []assets.ContentId {
+	ids := make([]assets.ContentId, len(host.Current.Preloads))
+	for i, id := range host.Current.Preloads {
+		ids[i] = assets.ContentId(id)
+	}
+
+	return ids
+}
+
+// Gizmos returns every gizmo drawn so far, decoded into generic msgpack
+// values.
+func (rt *Runtime) Gizmos() []any {
+	return host.Current.Gizmos
+}
+
+// RegisteredActions returns the ids of the actions registered with the host.
+func (rt *Runtime) RegisteredActions() []string {
+	ids := make([]string, len(host.Current.Input.Registered))
+	for i, action := range host.Current.Input.Registered {
+		ids[i] = action.Id
+	}
+
+	return ids
+}
+
+func (rt *Runtime) SetAction(id string, state input.ActionState) {
+	s := host.ActionState{
+		Kind:         state.Kind,
+		CockpitIndex: state.CockpitIndex,
+	}
+
+	if state.UV != nil {
+		s.UV = &[2]float32{state.UV.X, state.UV.Y}
+	}
+
+	host.Current.Input.States[id] = s
+}
+
+func (rt *Runtime) SetMouseDelta(delta lmath.Vec2) {
+	host.Current.Input.MouseDelta = [2]float32{delta.X, delta.Y}
+}
+
+// Deliver queues an incoming message for the next late_tick.
+func (rt *Runtime) Deliver(msg message.Message, source message.MessageSource) {
+	meta := msg.Meta()
+	host.Current.Messages.Inbox = append(host.Current.Messages.Inbox, host.IncomingMessage{
+		Meta: host.MessageMeta(meta),
+		Source: host.MessageSource{
+			Coupling:               source.Coupling,
+			ModuleSlotIndex:        source.ModuleSlotIndex,
+			ModuleSlotCockpitIndex: source.ModuleSlotCockpitIndex,
+		},
+		Value: msg,
+	})
+}
+
+type Sent struct {
+	Meta message.Meta
+	// Targets holds the targets as the host received them, e.g. "Myself" or
+	// map[string]any{"ChildByIndex": int64(0)}.
+	Targets []any
+
+	value any
+}
+
+// Decode decodes the payload of a sent message into v.
+func (s Sent) Decode(v any) error {
+	data, err := msgpack.Marshal(s.value)
+	if err != nil {
+		return err
+	}
+
+	return msgpack.NewReader(data).Decode(v)
+}
+
+func (rt *Runtime) Sent() []Sent {
+	sent := make([]Sent, len(host.Current.Messages.Sent))
+	for i, s := range host.Current.Messages.Sent {
+		sent[i] = Sent{
+			Meta:    message.Meta(s.Meta),
+			Targets: s.Targets,
+			value:   s.Value,
+		}
+	}
+
+	return sent
+}
+
+func (rt *Runtime) ClearSent() {
+	host.Current.Messages.Sent = nil
+}
+
+// SentOf returns the decoded payloads of every sent message of type T.
+func SentOf[T message.Message](rt *Runtime) []T {
+	rt.t.Helper()
+
+	var proto T
+	meta := proto.Meta()
+
+	var payloads []T
+	for _, s := range rt.Sent() {
+		if s.Meta != meta {
+			continue
+		}
+
+		var payload T
+		if err := s.Decode(&payload); err != nil {
+			rt.t.Fatalf("decode %s.%s: %v", meta.Namespace, meta.Identifier, err)
+		}
+
+		payloads = append(payloads, payload)
+	}
+
+	return payloads
+}
+
+// Texture returns the simulated canvas behind a texture. Pixels reflect all
+// actions flushed so far.
+func (rt *Runtime) Texture(t texture.Texture) *Canvas {
+	return host.Current.Texture(uint32(t))
+}
+
+// HostTexture creates a texture the way the host or another script would,
+// without the script knowing its size, and returns its handle.
+func (rt *Runtime) HostTexture(width, height int) uint32 {
+	return host.Current.CreateTexture(host.TextureOptions{Width: width, Height: height})
+}
+
+// AddFont makes a bitmap font available to font.LoadBitmapFontProperties.
+func (rt *Runtime) AddFont(id assets.ContentId, props font.BitmapFontProperties) {
+	letters := make(map[string]host.FontLetter, len(props.Letters))
+	for k, letter := range props.Letters {
+		letters[k] = host.FontLetter(letter)
+	}
+
+	host.Current.Fonts[host.ContentId(id)] = host.BitmapFont{
+		HorizontalDistance: props.HorizontalDistance,
+		VerticalSize:       props.VerticalSize,
+		Letters:            letters,
+	}
+}
+
+func (rt *Runtime) Seed(seed uint64) {
+	host.Current.Seed(seed)
+}
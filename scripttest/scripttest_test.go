@@ -0,0 +1,146 @@
+package scripttest_test
+
+import (
+	"testing"
+	gotime "time"
+
+	"github.com/oriolus-software/script-go/input"
+	"github.com/oriolus-software/script-go/lmath"
+	"github.com/oriolus-software/script-go/message"
+	"github.com/oriolus-software/script-go/scripttest"
+	"github.com/oriolus-software/script-go/texture"
+	"github.com/oriolus-software/script-go/time"
+	"github.com/oriolus-software/script-go/vars"
+	"github.com/oriolus-software/script-go/vehicle"
+)
+
+type doorRequest struct {
+	Open bool `msgpack:"open"`
+}
+
+func (doorRequest) Meta() message.Meta {
+	return message.Meta{Namespace: "test", Identifier: "door_request"}
+}
+
+type doorState struct {
+	Open     bool   `msgpack:"open"`
+	Coupling string `msgpack:"coupling"`
+}
+
+func (doorState) Meta() message.Meta {
+	return message.Meta{Namespace: "test", Identifier: "door_state"}
+}
+
+func TestVars(t *testing.T) {
+	rt := scripttest.New(t)
+	rt.SetVar("throttle", 0.5)
+	rt.Export("tick", func() {
+		vars.SetF64("traction", vars.GetF64("throttle")*2)
+		vars.SetI64("ticks", int64(time.TicksAlive()))
+	})
+
+	rt.TickN(3)
+
+	if got := rt.Var("traction"); got != 1.0 {
+		t.Fatalf("expected traction 1.0, got %v", got)
+	}
+
+	if got := rt.Var("ticks"); got != int64(3) {
+		t.Fatalf("expected 3 ticks, got %v", got)
+	}
+}
+
+func TestMessages(t *testing.T) {
+	rt := scripttest.New(t)
+
+	message.RegisterHandler(func(in message.Incoming[doorRequest]) {
+		message.Send(doorState{Open: in.Payload.Open, Coupling: in.Source.Coupling}, message.Parent)
+	})
+
+	rt.Deliver(doorRequest{Open: true}, message.MessageSource{Coupling: "front"})
+	rt.Tick()
+
+	sent := scripttest.SentOf[doorState](rt)
+	if len(sent) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(sent))
+	}
+
+	if !sent[0].Open || sent[0].Coupling != "front" {
+		t.Fatalf("unexpected payload %+v", sent[0])
+	}
+
+	if targets := rt.Sent()[0].Targets; len(targets) != 1 || targets[0] != "Parent" {
+		t.Fatalf("unexpected targets %v", targets)
+	}
+}
+
+func TestNewResetsPackageState(t *testing.T) {
+	scripttest.New(t)
+
+	handled := 0
+	message.RegisterHandler(func(message.Incoming[doorRequest]) {
+		handled++
+	})
+	message.SetDeferred(true)
+
+	rt := scripttest.New(t)
+	rt.Deliver(doorRequest{}, message.MessageSource{})
+	rt.Export("tick", func() {
+		message.Send(doorState{}, message.Parent)
+		if len(rt.Sent()) != 1 {
+			t.Error("expected deferred sending to be turned off")
+		}
+	})
+	rt.Tick()
+
+	if handled != 0 {
+		t.Fatalf("expected the handler of the previous runtime to be dropped, got %d calls", handled)
+	}
+}
+
+func TestTexture(t *testing.T) {
+	rt := scripttest.New(t)
+
+	tex := texture.Create(texture.CreationOptions{Width: 4, Height: 4})
+	tex.Clear(texture.Color{R: 255, A: 255})
+	tex.DrawRect(lmath.UVec2{X: 1, Y: 1}, lmath.UVec2{X: 3, Y: 3}, texture.Color{B: 255, A: 255})
+	tex.Flush()
+
+	canvas := rt.Texture(tex)
+	if got := texture.Color(canvas.Pixel(0, 0)); got != (texture.Color{R: 255, A: 255}) {
+		t.Fatalf("expected red corner, got %v", got)
+	}
+
+	if got := tex.GetPixel(2, 2); got != (texture.Pixel{B: 255}) {
+		t.Fatalf("expected blue center, got %v", got)
+	}
+}
+
+func TestInputAndVehicle(t *testing.T) {
+	rt := scripttest.New(t)
+	rt.SetAction("brake", input.ActionState{Kind: input.KindPressed})
+	rt.Vehicle().Velocity = 10
+
+	rt.Export("tick", func() {
+		if !input.State("brake").IsPressed() {
+			return
+		}
+
+		axle, err := vehicle.Bogie(0).GetAxle(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		axle.SetBrakeForceNewton(vehicle.VelocityVsGround() * 100)
+	})
+
+	rt.Advance(gotime.Second / 30)
+
+	if got := rt.Vehicle().Bogies[0].Axles[1].BrakeForce; got != 1000 {
+		t.Fatalf("expected brake force 1000, got %v", got)
+	}
+
+	if _, err := vehicle.GetBogie(5); err != vehicle.ErrorBogieNotFound {
+		t.Fatalf("expected bogie not found, got %v", err)
+	}
+}
@@ -0,0 +1,205 @@
+package texture
+
+import (
+	"encoding/json"
+	"fmt"
+	gotime "time"
+
+	"github.com/oriolus-software/script-go/lmath"
+	"github.com/oriolus-software/script-go/time"
+)
+
+// Atlas names sub-rectangles of a texture, e.g. the lamps and icons of a
+// cockpit stored in a single image.
+type Atlas struct {
+	Texture    Texture
+	Sprites    map[string]lmath.Rectangle
+	Animations map[string]Animation
+}
+
+// Animation shows sprites of an atlas one after another.
+type Animation struct {
+	Frames        []string `json:"frames"`
+	FrameDuration float64  `json:"frame_duration"` // seconds
+	// Loop restarts the animation after the last frame. Otherwise the last
+	// frame stays.
+	Loop bool `json:"loop"`
+}
+
+// NewAtlas returns an empty atlas of t.
+func NewAtlas(t Texture) *Atlas {
+	return &Atlas{
+		Texture:    t,
+		Sprites:    make(map[string]lmath.Rectangle),
+		Animations: make(map[string]Animation),
+	}
+}
+
+// ParseAtlas reads an atlas description of t:
+//
+//	{
+//		"sprites": {
+//			"lamp_off": {"x": 0, "y": 0, "width": 16, "height": 16},
+//			"lamp_on": {"x": 16, "y": 0, "width": 16, "height": 16}
+//		},
+//		"animations": {
+//			"lamp_blink": {"frames": ["lamp_on", "lamp_off"], "frame_duration": 0.5, "loop": true}
+//		}
+//	}
+func ParseAtlas(t Texture, data []byte) (*Atlas, error) {
+	var desc struct {
+		Sprites map[string]struct {
+			X      uint `json:"x"`
+			Y      uint `json:"y"`
+			Width  uint `json:"width"`
+			Height uint `json:"height"`
+		} `json:"sprites"`
+		Animations map[string]Animation `json:"animations"`
+	}
+
+	if err := json.Unmarshal(data, &desc); err != nil {
+		return nil, fmt.Errorf("parse atlas: %w", err)
+	}
+
+	a := NewAtlas(t)
+	for name, s := range desc.Sprites {
+		a.Add(name, lmath.Rectangle{
+			Start: lmath.UVec2{X: s.X, Y: s.Y},
+			End:   lmath.UVec2{X: s.X + s.Width, Y: s.Y + s.Height},
+		})
+	}
+
+	for name, animation := range desc.Animations {
+		if err := a.check(name, animation); err != nil {
+			return nil, fmt.Errorf("parse atlas: %w", err)
+		}
+
+		a.Animations[name] = animation
+	}
+
+	return a, nil
+}
+
+// Add names a rectangle of the atlas, end exclusive.
+func (a *Atlas) Add(name string, rect lmath.Rectangle) {
+	a.Sprites[name] = rect
+}
+
+// AddAnimation names an animation of sprites of the atlas. It panics if the
+// animation has no frames, no positive frame duration or unknown sprites.
+func (a *Atlas) AddAnimation(name string, animation Animation) {
+	if err := a.check(name, animation); err != nil {
+		panic(err.Error())
+	}
+
+	a.Animations[name] = animation
+}
+
+func (a *Atlas) check(name string, animation Animation) error {
+	if len(animation.Frames) == 0 || animation.FrameDuration <= 0 {
+		return fmt.Errorf("animation %s needs frames and a positive frame duration", name)
+	}
+
+	for _, frame := range animation.Frames {
+		if _, ok := a.Sprites[frame]; !ok {
+			return fmt.Errorf("animation %s uses unknown sprite %s", name, frame)
+		}
+	}
+
+	return nil
+}
+
+// AddGrid names the cells of a grid of equally sized sprites starting at
+// origin, row by row, as prefix0, prefix1 and so on.
+func (a *Atlas) AddGrid(prefix string, origin, size lmath.UVec2, columns, rows int) {
+	for row := 0; row < rows; row++ {
+		for column := 0; column < columns; column++ {
+			start := lmath.UVec2{X: origin.X + uint(column)*size.X, Y: origin.Y + uint(row)*size.Y}
+			a.Add(fmt.Sprintf("%s%d", prefix, row*columns+column), lmath.Rectangle{
+				Start: start,
+				End:   lmath.UVec2{X: start.X + size.X, Y: start.Y + size.Y},
+			})
+		}
+	}
+}
+
+// Draw draws a sprite onto target with its top left corner at pos. It
+// panics if the atlas has no such sprite.
+func (a *Atlas) Draw(target Texture, name string, pos lmath.UVec2) {
+	rect := a.sprite(name)
+	a.DrawScaled(target, name, lmath.Rectangle{
+		Start: pos,
+		End:   lmath.UVec2{X: pos.X + rect.End.X - rect.Start.X, Y: pos.Y + rect.End.Y - rect.Start.Y},
+	})
+}
+
+// DrawScaled draws a sprite stretched to the target rectangle.
+func (a *Atlas) DrawScaled(target Texture, name string, rect lmath.Rectangle) {
+	target.DrawScriptTexture(a.Texture, DrawTextureOptions{
+		SourceRect: a.sprite(name),
+		TargetRect: rect,
+	})
+}
+
+func (a *Atlas) sprite(name string) lmath.Rectangle {
+	rect, ok := a.Sprites[name]
+	if !ok {
+		panic(fmt.Sprintf("atlas has no sprite %q", name))
+	}
+
+	return rect
+}
+
+// AnimatedSprite plays an animation of an atlas in game time.
+type AnimatedSprite struct {
+	atlas     *Atlas
+	animation Animation
+	start     gotime.Time
+}
+
+// Animate starts an animation at the current game time. It panics if the
+// atlas has no such animation or the animation is invalid, see AddAnimation.
+func (a *Atlas) Animate(name string) *AnimatedSprite {
+	animation, ok := a.Animations[name]
+	if !ok {
+		panic(fmt.Sprintf("atlas has no animation %q", name))
+	}
+
+	if err := a.check(name, animation); err != nil {
+		panic(err.Error())
+	}
+
+	return &AnimatedSprite{atlas: a, animation: animation, start: time.GetGameTime()}
+}
+
+// Restart starts the animation again at the current game time.
+func (s *AnimatedSprite) Restart() {
+	s.start = time.GetGameTime()
+}
+
+// Frame returns the name of the sprite shown at the current game time.
+func (s *AnimatedSprite) Frame() string {
+	frames := s.animation.Frames
+	elapsed := time.GetGameTime().Sub(s.start).Seconds()
+
+	i := max(0, int(elapsed/s.animation.FrameDuration))
+	if s.animation.Loop {
+		i %= len(frames)
+	} else {
+		i = min(i, len(frames)-1)
+	}
+
+	return frames[i]
+}
+
+// Done reports whether an animation that does not loop shows its last
+// frame.
+func (s *AnimatedSprite) Done() bool {
+	elapsed := time.GetGameTime().Sub(s.start).Seconds()
+	return !s.animation.Loop && int(elapsed/s.animation.FrameDuration) >= len(s.animation.Frames)-1
+}
+
+// Draw draws the current frame onto target with its top left corner at pos.
+func (s *AnimatedSprite) Draw(target Texture, pos lmath.UVec2) {
+	s.atlas.Draw(target, s.Frame(), pos)
+}
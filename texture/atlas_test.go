@@ -0,0 +1,162 @@
+package texture_test
+
+import (
+	"testing"
+
+	"github.com/oriolus-software/script-go/lmath"
+	"github.com/oriolus-software/script-go/scripttest"
+	"github.com/oriolus-software/script-go/texture"
+)
+
+const lamps = `{
+	"sprites": {
+		"lamp_off": {"x": 0, "y": 0, "width": 4, "height": 4},
+		"lamp_on": {"x": 4, "y": 0, "width": 4, "height": 4}
+	},
+	"animations": {
+		"lamp_blink": {"frames": ["lamp_on", "lamp_off"], "frame_duration": 0.5, "loop": true},
+		"lamp_test": {"frames": ["lamp_on", "lamp_off"], "frame_duration": 0.5}
+	}
+}`
+
+var green = texture.Color{G: 255, A: 255}
+
+// lampAtlas returns an atlas with a black and a green lamp.
+func lampAtlas(t *testing.T) *texture.Atlas {
+	t.Helper()
+
+	sheet := texture.Create(texture.CreationOptions{Width: 8, Height: 4})
+	sheet.Clear(black)
+	sheet.DrawRect(lmath.UVec2{X: 4}, lmath.UVec2{X: 8, Y: 4}, green)
+	sheet.Flush()
+
+	atlas, err := texture.ParseAtlas(sheet, []byte(lamps))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return atlas
+}
+
+func TestAtlasDraw(t *testing.T) {
+	rt := scripttest.New(t)
+	atlas := lampAtlas(t)
+
+	target := texture.Create(texture.CreationOptions{Width: 16, Height: 16})
+	target.Clear(white)
+	atlas.Draw(target, "lamp_on", lmath.UVec2{X: 2, Y: 3})
+	atlas.DrawScaled(target, "lamp_off", lmath.Rectangle{Start: lmath.UVec2{X: 8, Y: 8}, End: lmath.UVec2{X: 16, Y: 16}})
+	target.Flush()
+
+	canvas := rt.Texture(target)
+	checks := []struct {
+		x, y int
+		want texture.Color
+	}{
+		{1, 3, white},
+		{2, 3, green},
+		{5, 6, green},
+		{6, 7, white},
+		{8, 8, black},
+		{15, 15, black},
+	}
+
+	for _, c := range checks {
+		if got := texture.Color(canvas.Pixel(c.x, c.y)); got != c.want {
+			t.Errorf("pixel (%d, %d) = %v, want %v", c.x, c.y, got, c.want)
+		}
+	}
+
+	// Like DrawText, copies default to the opaque alpha mode.
+	action := canvas.Actions[len(canvas.Actions)-1].(map[string]any)["DrawScriptTexture"].(map[string]any)
+	if mode := action["options"].(map[string]any)["alpha_mode"]; mode != "Opaque" {
+		t.Errorf("alpha mode = %v, want Opaque", mode)
+	}
+}
+
+func TestAtlasAnimation(t *testing.T) {
+	rt := scripttest.New(t)
+	rt.SetDelta(0.25)
+	atlas := lampAtlas(t)
+
+	blink := atlas.Animate("lamp_blink")
+	once := atlas.Animate("lamp_test")
+
+	frames := []string{}
+	for i := 0; i < 5; i++ {
+		frames = append(frames, blink.Frame())
+		rt.Tick()
+	}
+
+	want := []string{"lamp_on", "lamp_on", "lamp_off", "lamp_off", "lamp_on"}
+	for i := range want {
+		if frames[i] != want[i] {
+			t.Fatalf("frames %v, want %v", frames, want)
+		}
+	}
+
+	if once.Frame() != "lamp_off" || !once.Done() {
+		t.Fatalf("animation without loop shows %s, done %v", once.Frame(), once.Done())
+	}
+
+	once.Restart()
+	if once.Frame() != "lamp_on" || once.Done() {
+		t.Fatalf("restarted animation shows %s, done %v", once.Frame(), once.Done())
+	}
+}
+
+func TestAtlasGridAndErrors(t *testing.T) {
+	scripttest.New(t)
+
+	atlas := texture.NewAtlas(texture.Create(texture.CreationOptions{Width: 12, Height: 8}))
+	atlas.AddGrid("digit", lmath.UVec2{}, lmath.UVec2{X: 4, Y: 4}, 3, 2)
+
+	want := lmath.Rectangle{Start: lmath.UVec2{X: 4, Y: 4}, End: lmath.UVec2{X: 8, Y: 8}}
+	if got := atlas.Sprites["digit4"]; got != want || len(atlas.Sprites) != 6 {
+		t.Fatalf("digit4 = %v of %d sprites", got, len(atlas.Sprites))
+	}
+
+	_, err := texture.ParseAtlas(0, []byte(`{"animations": {"a": {"frames": ["missing"], "frame_duration": 1}}}`))
+	if err == nil {
+		t.Fatal("animation with an unknown sprite was accepted")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("drawing an unknown sprite did not panic")
+		}
+	}()
+	atlas.Draw(0, "digit9", lmath.UVec2{})
+}
+
+func TestAtlasInvalidAnimation(t *testing.T) {
+	scripttest.New(t)
+	atlas := lampAtlas(t)
+
+	panics := func(name string, f func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s did not panic", name)
+			}
+		}()
+		f()
+	}
+
+	panics("animation without frames", func() {
+		atlas.AddAnimation("empty", texture.Animation{FrameDuration: 1})
+	})
+	panics("animation with an unknown sprite", func() {
+		atlas.AddAnimation("unknown", texture.Animation{Frames: []string{"missing"}, FrameDuration: 1})
+	})
+
+	atlas.Animations["still"] = texture.Animation{Frames: []string{"lamp_on"}}
+	panics("animation without a frame duration", func() {
+		atlas.Animate("still")
+	})
+
+	atlas.AddAnimation("flash", texture.Animation{Frames: []string{"lamp_on", "lamp_off"}, FrameDuration: 0.1})
+	if got := atlas.Animate("flash").Frame(); got != "lamp_on" {
+		t.Fatalf("flash shows %s", got)
+	}
+}
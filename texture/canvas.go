@@ -0,0 +1,167 @@
+package texture
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// maxDirtyRects is the number of separate dirty rectangles a canvas tracks
+// before merging them into their bounding box.
+const maxDirtyRects = 16
+
+// Canvas is a texture with a copy of its pixels kept by the script. Drawing
+// only changes the copy, Flush sends the pixels that changed since the last
+// flush. Canvas implements draw.Image, so the image and image/draw packages
+// can draw onto it.
+//
+// A new canvas assumes the texture is fully transparent.
+type Canvas struct {
+	texture Texture
+	pixels  *image.NRGBA
+	shown   *image.NRGBA
+	dirty   []image.Rectangle
+}
+
+// NewCanvas creates a texture and a canvas drawing onto it.
+func NewCanvas(opts CreationOptions) *Canvas {
+	bounds := image.Rect(0, 0, opts.Width, opts.Height)
+
+	return &Canvas{
+		texture: Create(opts),
+		pixels:  image.NewNRGBA(bounds),
+		shown:   image.NewNRGBA(bounds),
+	}
+}
+
+// Texture returns the texture the canvas draws onto.
+func (c *Canvas) Texture() Texture {
+	return c.texture
+}
+
+func (c *Canvas) ColorModel() color.Model {
+	return color.NRGBAModel
+}
+
+func (c *Canvas) Bounds() image.Rectangle {
+	return c.pixels.Rect
+}
+
+func (c *Canvas) At(x, y int) color.Color {
+	return c.pixels.At(x, y)
+}
+
+func (c *Canvas) Set(x, y int, col color.Color) {
+	if !image.Pt(x, y).In(c.pixels.Rect) {
+		return
+	}
+
+	c.pixels.Set(x, y, col)
+	c.markDirty(image.Rect(x, y, x+1, y+1))
+}
+
+// SetColor sets a single pixel.
+func (c *Canvas) SetColor(x, y int, col Color) {
+	c.Set(x, y, color.NRGBA(col))
+}
+
+// Fill sets every pixel in r.
+func (c *Canvas) Fill(r image.Rectangle, col Color) {
+	c.Draw(r, image.NewUniform(color.NRGBA(col)), image.Point{}, draw.Src)
+}
+
+// Draw works like draw.Draw with the canvas as destination, but marks r
+// dirty once instead of every pixel.
+func (c *Canvas) Draw(r image.Rectangle, src image.Image, sp image.Point, op draw.Op) {
+	clipped := r.Intersect(c.pixels.Rect)
+	if clipped.Empty() {
+		return
+	}
+
+	// draw.Draw clips r itself and moves sp along, so it gets r unclipped.
+	draw.Draw(c.pixels, r, src, sp, op)
+	c.markDirty(clipped)
+}
+
+func (c *Canvas) markDirty(r image.Rectangle) {
+	for {
+		merged := false
+		for i, d := range c.dirty {
+			// Touching rectangles are merged as well.
+			if d.Inset(-1).Overlaps(r) {
+				r = r.Union(d)
+				c.dirty = append(c.dirty[:i], c.dirty[i+1:]...)
+				merged = true
+				break
+			}
+		}
+
+		if !merged {
+			break
+		}
+	}
+
+	c.dirty = append(c.dirty, r)
+
+	if len(c.dirty) > maxDirtyRects {
+		bounds := c.dirty[0]
+		for _, d := range c.dirty[1:] {
+			bounds = bounds.Union(d)
+		}
+		c.dirty = []image.Rectangle{bounds}
+	}
+}
+
+// Flush sends the pixels changed since the last flush and flushes the
+// texture. Runs of equal pixels become DrawRect actions, the remaining
+// pixels DrawPixels actions.
+func (c *Canvas) Flush() {
+	if len(c.dirty) == 0 {
+		return
+	}
+
+	r := newRaster(c.texture)
+	for _, d := range c.dirty {
+		c.diff(r, d)
+	}
+	c.dirty = c.dirty[:0]
+
+	r.draw(c.texture)
+	c.texture.Flush()
+}
+
+// diff adds the pixels in d that differ from the last flush to r.
+func (c *Canvas) diff(r *raster, d image.Rectangle) {
+	for y := d.Min.Y; y < d.Max.Y; y++ {
+		start := -1
+		var startColor Color
+
+		for x := d.Min.X; x <= d.Max.X; x++ {
+			var col Color
+			changed := false
+			if x < d.Max.X {
+				col = Color(c.pixels.NRGBAAt(x, y))
+				changed = col != Color(c.shown.NRGBAAt(x, y))
+			}
+
+			if start >= 0 && (!changed || col != startColor) {
+				r.span(y, start, x, startColor)
+				start = -1
+			}
+
+			if !changed {
+				continue
+			}
+
+			if start < 0 {
+				start, startColor = x, col
+			}
+			c.shown.SetNRGBA(x, y, color.NRGBA(col))
+		}
+	}
+}
+
+// Dispose disposes the texture.
+func (c *Canvas) Dispose() {
+	c.texture.Dispose()
+}
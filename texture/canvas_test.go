@@ -0,0 +1,97 @@
+package texture_test
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/oriolus-software/script-go/scripttest"
+	"github.com/oriolus-software/script-go/texture"
+)
+
+var _ draw.Image = (*texture.Canvas)(nil)
+
+func TestCanvasFlushesChanges(t *testing.T) {
+	rt := scripttest.New(t)
+
+	c := texture.NewCanvas(texture.CreationOptions{Width: 8, Height: 4})
+	c.Fill(c.Bounds(), black)
+	c.Flush()
+
+	host := rt.Texture(c.Texture())
+	if len(host.Actions) != 1 {
+		t.Fatalf("got %d actions for a full fill, want 1", len(host.Actions))
+	}
+
+	// The clock digit changes, everything else stays.
+	c.Fill(image.Rect(2, 1, 4, 3), white)
+	c.SetColor(6, 0, white)
+	c.Set(7, 3, color.NRGBA{R: 255, A: 255})
+	c.Flush()
+
+	if len(host.Actions) != 3 {
+		t.Fatalf("got %d actions, want 3", len(host.Actions))
+	}
+
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 8; x++ {
+			want := texture.Color(c.At(x, y).(color.NRGBA))
+			if got := texture.Color(host.Pixel(x, y)); got != want {
+				t.Fatalf("pixel (%d, %d) = %v, want %v", x, y, got, want)
+			}
+		}
+	}
+
+	// Redrawing the same content sends nothing.
+	c.Fill(image.Rect(2, 1, 4, 3), white)
+	c.Flush()
+
+	if len(host.Actions) != 3 {
+		t.Fatalf("unchanged redraw sent %d actions", len(host.Actions)-3)
+	}
+}
+
+func TestCanvasDraw(t *testing.T) {
+	rt := scripttest.New(t)
+
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	src.Set(0, 0, color.NRGBA{G: 255, A: 255})
+	src.Set(1, 1, color.NRGBA{B: 255, A: 255})
+
+	c := texture.NewCanvas(texture.CreationOptions{Width: 4, Height: 4})
+	draw.Draw(c, image.Rect(1, 1, 3, 3), src, image.Point{}, draw.Src)
+	c.Flush()
+
+	host := rt.Texture(c.Texture())
+	if got := texture.Color(host.Pixel(1, 1)); got != (texture.Color{G: 255, A: 255}) {
+		t.Fatalf("pixel (1, 1) = %v", got)
+	}
+
+	if got := texture.Color(host.Pixel(2, 2)); got != (texture.Color{B: 255, A: 255}) {
+		t.Fatalf("pixel (2, 2) = %v", got)
+	}
+}
+
+func TestCanvasDrawClipped(t *testing.T) {
+	rt := scripttest.New(t)
+
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	src.Set(0, 0, color.NRGBA{G: 255, A: 255})
+	src.Set(1, 1, color.NRGBA{B: 255, A: 255})
+
+	// Only one corner of the source lands on the canvas each time.
+	c := texture.NewCanvas(texture.CreationOptions{Width: 4, Height: 4})
+	c.Draw(image.Rect(-1, -1, 1, 1), src, image.Point{}, draw.Src)
+	c.Draw(image.Rect(3, 3, 5, 5), src, image.Point{}, draw.Src)
+	c.Flush()
+
+	host := rt.Texture(c.Texture())
+	if got := texture.Color(host.Pixel(0, 0)); got != (texture.Color{B: 255, A: 255}) {
+		t.Fatalf("pixel (0, 0) = %v", got)
+	}
+
+	if got := texture.Color(host.Pixel(3, 3)); got != (texture.Color{G: 255, A: 255}) {
+		t.Fatalf("pixel (3, 3) = %v", got)
+	}
+}
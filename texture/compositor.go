@@ -0,0 +1,119 @@
+package texture
+
+// Layer is a texture drawn by a Compositor. Its render function only runs
+// when the layer was invalidated.
+type Layer struct {
+	Texture   Texture
+	AlphaMode AlphaMode
+
+	render     func(Texture)
+	compositor *Compositor
+	dirty      bool
+	hidden     bool
+}
+
+// Invalidate renders the layer again on the next Render.
+func (l *Layer) Invalidate() {
+	l.dirty = true
+}
+
+// SetVisible shows or hides the layer.
+func (l *Layer) SetVisible(visible bool) {
+	if l.hidden == !visible {
+		return
+	}
+
+	l.hidden = !visible
+	l.compositor.recompose = true
+}
+
+// Compositor composes layers into one texture, e.g. a static background,
+// dynamic content and an overlay of a cockpit screen. Layers are drawn in
+// the order they were added.
+type Compositor struct {
+	target    Texture
+	opts      CreationOptions
+	layers    []*Layer
+	recompose bool
+}
+
+// NewCompositor creates the texture the layers are composed into. Expose
+// or apply Texture to show the result.
+func NewCompositor(opts CreationOptions) *Compositor {
+	return &Compositor{target: Create(opts), opts: opts}
+}
+
+// Texture returns the composed texture.
+func (c *Compositor) Texture() Texture {
+	return c.target
+}
+
+// AddLayer adds a layer on top of the others. render draws the layer onto
+// its texture, which is cleared to transparent before. A nil mode composes
+// the layer opaquely.
+func (c *Compositor) AddLayer(mode AlphaMode, render func(Texture)) *Layer {
+	l := &Layer{
+		Texture:    Create(CreationOptions{Width: c.opts.Width, Height: c.opts.Height}),
+		AlphaMode:  mode,
+		render:     render,
+		compositor: c,
+		dirty:      true,
+	}
+
+	c.layers = append(c.layers, l)
+	return l
+}
+
+// Render renders the invalidated layers and composes all visible layers
+// if any of them changed. It reports whether the composed texture changed.
+func (c *Compositor) Render() bool {
+	changed := c.recompose
+
+	for _, l := range c.layers {
+		if !l.dirty {
+			continue
+		}
+
+		l.Texture.Clear(Color{})
+		l.render(l.Texture)
+		l.Texture.Flush()
+
+		l.dirty = false
+		changed = changed || !l.hidden
+	}
+
+	if !changed {
+		return false
+	}
+
+	full := DrawTextureOptions{}
+	full.SourceRect.End.X, full.SourceRect.End.Y = uint(c.opts.Width), uint(c.opts.Height)
+	full.TargetRect = full.SourceRect
+
+	c.target.Clear(Color{})
+	for _, l := range c.layers {
+		if l.hidden {
+			continue
+		}
+
+		opts := full
+		opts.AlphaMode = l.AlphaMode
+		if opts.AlphaMode == nil {
+			opts.AlphaMode = AlphaOpaque
+		}
+
+		c.target.DrawScriptTexture(l.Texture, opts)
+	}
+
+	c.recompose = false
+	return true
+}
+
+// Dispose disposes the layers and the composed texture.
+func (c *Compositor) Dispose() {
+	for _, l := range c.layers {
+		l.Texture.Dispose()
+	}
+
+	c.target.Dispose()
+}
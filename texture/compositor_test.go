@@ -0,0 +1,87 @@
+package texture_test
+
+import (
+	"testing"
+
+	"github.com/oriolus-software/script-go/lmath"
+	"github.com/oriolus-software/script-go/scripttest"
+	"github.com/oriolus-software/script-go/texture"
+)
+
+func TestCompositor(t *testing.T) {
+	rt := scripttest.New(t)
+
+	c := texture.NewCompositor(texture.CreationOptions{Width: 8, Height: 8})
+
+	renders := map[string]int{}
+	needle := uint(0)
+
+	c.AddLayer(nil, func(tex texture.Texture) {
+		renders["background"]++
+		tex.Clear(black)
+	})
+
+	content := c.AddLayer(texture.AlphaMask(0.5), func(tex texture.Texture) {
+		renders["content"]++
+		tex.DrawRect(lmath.UVec2{X: needle}, lmath.UVec2{X: needle + 1, Y: 8}, white)
+	})
+
+	overlay := c.AddLayer(texture.AlphaBlend, func(tex texture.Texture) {
+		renders["overlay"]++
+		tex.DrawRect(lmath.UVec2{X: 4}, lmath.UVec2{X: 8, Y: 8}, texture.Color{R: 255, A: 128})
+	})
+
+	if !c.Render() {
+		t.Fatal("first render did not compose")
+	}
+	c.Texture().Flush()
+
+	pixel := func(x, y int) texture.Color {
+		return texture.Color(rt.Texture(c.Texture()).Pixel(x, y))
+	}
+
+	if got := pixel(0, 0); got != white {
+		t.Fatalf("needle pixel = %v", got)
+	}
+
+	if got := pixel(1, 0); got != black {
+		t.Fatalf("background pixel = %v", got)
+	}
+
+	// The simulated host blends with straight alpha, the game may round
+	// differently.
+	if got := pixel(5, 0); got.R == 0 || got.R == 255 || got.A != 255 {
+		t.Fatalf("blended overlay pixel = %v", got)
+	}
+
+	if c.Render() {
+		t.Fatal("render without changes composed again")
+	}
+
+	needle = 2
+	content.Invalidate()
+	c.Render()
+	c.Texture().Flush()
+
+	if renders["background"] != 1 || renders["content"] != 2 || renders["overlay"] != 1 {
+		t.Fatalf("renders %v", renders)
+	}
+
+	if pixel(0, 0) != black || pixel(2, 0) != white {
+		t.Fatalf("needle did not move: %v %v", pixel(0, 0), pixel(2, 0))
+	}
+
+	overlay.SetVisible(false)
+	if !c.Render() {
+		t.Fatal("hiding a layer did not compose")
+	}
+	c.Texture().Flush()
+
+	if got := pixel(5, 0); got != black {
+		t.Fatalf("hidden overlay still visible: %v", got)
+	}
+
+	if renders["overlay"] != 1 {
+		t.Fatalf("hiding re-rendered the overlay: %v", renders)
+	}
+}
@@ -0,0 +1,107 @@
+package texture
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/oriolus-software/script-go/lmath"
+)
+
+// sizes holds the size of every texture created by the script or passed to
+// FromHandle.
+var sizes = make(map[Texture]image.Point)
+
+// FromHandle returns the texture with the given host handle, e.g. one passed
+// in by another script, and records its size. The size is only known for
+// textures the script created, so Bounds, ToImage and the shapes need it for
+// other textures.
+func FromHandle(handle uint32, width, height int) Texture {
+	t := Texture(handle)
+	sizes[t] = image.Pt(width, height)
+	return t
+}
+
+// ColorModel, Bounds and At make Texture an image.Image reading back the
+// pixels of the texture. Only flushed actions are visible.
+
+func (t Texture) ColorModel() color.Model {
+	return color.NRGBAModel
+}
+
+// Bounds returns the size the texture was created with, or the one passed
+// to FromHandle. It is empty for other textures and disposed ones.
+func (t Texture) Bounds() image.Rectangle {
+	size := sizes[t]
+	return image.Rect(0, 0, size.X, size.Y)
+}
+
+// At reads a single pixel with at least one host call, which is slow when
+// reading many pixels, e.g. when passing the texture to draw.Draw or
+// png.Encode. Pass the image returned by ToImage instead, or read the
+// needed area with ReadRegion.
+func (t Texture) At(x, y int) color.Color {
+	if !image.Pt(x, y).In(t.Bounds()) {
+		return color.NRGBA{}
+	}
+
+	return color.NRGBA(t.GetColor(x, y))
+}
+
+// ToImage returns a snapshot of the pixels of the texture, read back with
+// ReadRegion. Only flushed actions are visible. The image is empty for
+// textures of unknown size, see FromHandle.
+func (t Texture) ToImage() *image.NRGBA {
+	bounds := t.Bounds()
+	img := image.NewNRGBA(bounds)
+
+	colors := t.ReadRegion(lmath.Rectangle{
+		End: lmath.UVec2{X: uint(bounds.Dx()), Y: uint(bounds.Dy())},
+	})
+	for i, c := range colors {
+		copy(img.Pix[4*i:], []uint8{c.R, c.G, c.B, c.A})
+	}
+
+	return img
+}
+
+// FromImage creates a texture and draws img onto it. A zero width or height
+// in opts is taken from the image. Fully transparent pixels are skipped and
+// runs of equal pixels are sent as rectangles.
+func FromImage(img image.Image, opts CreationOptions) Texture {
+	bounds := img.Bounds()
+	if opts.Width == 0 {
+		opts.Width = bounds.Dx()
+	}
+
+	if opts.Height == 0 {
+		opts.Height = bounds.Dy()
+	}
+
+	t := Create(opts)
+
+	r := newRaster(t)
+	for y := 0; y < min(opts.Height, bounds.Dy()); y++ {
+		start := -1
+		var startColor Color
+
+		width := min(opts.Width, bounds.Dx())
+		for x := 0; x <= width; x++ {
+			var col Color
+			if x < width {
+				col = Color(color.NRGBAModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.NRGBA))
+			}
+
+			if start >= 0 && col != startColor {
+				r.span(y, start, x, startColor)
+				start = -1
+			}
+
+			if start < 0 && col.A != 0 {
+				start, startColor = x, col
+			}
+		}
+	}
+	r.draw(t)
+
+	return t
+}
@@ -0,0 +1,104 @@
+package texture_test
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/oriolus-software/script-go/scripttest"
+	"github.com/oriolus-software/script-go/texture"
+)
+
+var _ image.Image = texture.Texture(0)
+
+func TestFromImage(t *testing.T) {
+	rt := scripttest.New(t)
+
+	// A noisy image, so most pixels end up in DrawPixels actions.
+	img := image.NewNRGBA(image.Rect(10, 10, 50, 50))
+	for y := 10; y < 50; y++ {
+		for x := 10; x < 50; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x * 7), G: uint8(y * 13), B: uint8(x * y), A: 255})
+		}
+	}
+	draw.Draw(img, image.Rect(10, 10, 30, 20), image.NewUniform(color.NRGBA{G: 255, A: 255}), image.Point{}, draw.Src)
+
+	tex := texture.FromImage(img, texture.CreationOptions{})
+	tex.Flush()
+
+	if got := tex.Bounds(); got != image.Rect(0, 0, 40, 40) {
+		t.Fatalf("bounds = %v", got)
+	}
+
+	snapshot := tex.ToImage()
+	if snapshot.Rect != tex.Bounds() {
+		t.Fatalf("snapshot bounds = %v", snapshot.Rect)
+	}
+
+	for y := 0; y < 40; y++ {
+		for x := 0; x < 40; x++ {
+			want := img.NRGBAAt(x+10, y+10)
+			if got := snapshot.NRGBAAt(x, y); got != want {
+				t.Fatalf("pixel (%d, %d) = %v, want %v", x, y, got, want)
+			}
+		}
+	}
+
+	actions := rt.Texture(tex).Actions
+	pixelActions := 0
+	for _, action := range actions {
+		if pixels, ok := action.(map[string]any)["DrawPixels"].([]any); ok {
+			pixelActions++
+			if len(pixels) > 512 {
+				t.Fatalf("DrawPixels action with %d pixels", len(pixels))
+			}
+		}
+	}
+
+	if pixelActions < 2 {
+		t.Fatalf("got %d DrawPixels actions, want the pixels split up", pixelActions)
+	}
+}
+
+func TestTextureAsImage(t *testing.T) {
+	scripttest.New(t)
+
+	tex := texture.Create(texture.CreationOptions{Width: 3, Height: 2})
+	tex.Clear(texture.Color{B: 255, A: 255})
+	tex.Flush()
+
+	dst := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	draw.Draw(dst, dst.Rect, tex, image.Point{}, draw.Src)
+
+	if got := dst.NRGBAAt(2, 1); got != (color.NRGBA{B: 255, A: 255}) {
+		t.Fatalf("pixel (2, 1) = %v", got)
+	}
+
+	if got := tex.At(3, 0); got != (color.NRGBA{}) {
+		t.Fatalf("pixel outside of the texture = %v", got)
+	}
+
+	tex.Dispose()
+	if !tex.Bounds().Empty() {
+		t.Fatalf("disposed texture has bounds %v", tex.Bounds())
+	}
+}
+
+func TestTextureFromHandle(t *testing.T) {
+	rt := scripttest.New(t)
+
+	handle := rt.HostTexture(2, 2)
+	if bounds := texture.Texture(handle).Bounds(); !bounds.Empty() {
+		t.Fatalf("texture of unknown size has bounds %v", bounds)
+	}
+
+	tex := texture.FromHandle(handle, 2, 2)
+	tex.Clear(texture.Color{R: 255, A: 255})
+	tex.Flush()
+
+	img := tex.ToImage()
+	if img.Bounds() != image.Rect(0, 0, 2, 2) || img.NRGBAAt(1, 1) != (color.NRGBA{R: 255, A: 255}) {
+		t.Fatalf("got %v with pixel (1, 1) = %v", img.Bounds(), img.NRGBAAt(1, 1))
+	}
+}
@@ -0,0 +1,56 @@
+//go:build !wasm
+
+package texture
+
+import (
+	"image"
+
+	"github.com/oriolus-software/script-go/internal/ffi"
+	"github.com/oriolus-software/script-go/internal/host"
+)
+
+func create(opts uint64) uint32 {
+	return host.Current.CreateTexture(ffi.Deserialize[host.TextureOptions](opts))
+}
+
+func dispose(texture uint32) {
+	host.Current.Texture(texture).Disposed = true
+}
+
+func addAction(texture uint32, action uint64) {
+	host.Current.Texture(texture).AddAction(ffi.Deserialize[any](action))
+}
+
+func getPixel(texture uint32, x, y int) Pixel {
+	c := host.Current.Texture(texture).Pixel(x, y)
+	return Pixel{R: c.R, G: c.G, B: c.B}
+}
+
+// The simulated host provides read_region.
+const hasReadRegion = true
+
+func readRegion(texture uint32, x, y, width, height int) uint64 {
+	return ffi.Serialize(host.Current.Texture(texture).Region(x, y, width, height)).ToPacked()
+}
+
+func flushActions(texture uint32) {
+	host.Current.FlushActions(texture)
+}
+
+func applyTo(texture uint32, target uint64) {
+	c := host.Current.Texture(texture)
+	c.AppliedTo = append(c.AppliedTo, ffi.Deserialize[string](target))
+}
+
+func expose(texture uint32, name uint64) {
+	c := host.Current.Texture(texture)
+	c.Exposed = append(c.Exposed, ffi.Deserialize[string](name))
+}
+
+func init() {
+	host.OnReset(func() {
+		pending = make(map[Texture]struct{})
+		pendingOrder = nil
+		sizes = make(map[Texture]image.Point)
+	})
+}
@@ -0,0 +1,31 @@
+//go:build wasm
+
+package texture
+
+//go:wasm-module textures
+//export create
+func create(opts uint64) uint32
+
+//go:wasm-module textures
+//export dispose
+func dispose(texture uint32)
+
+//go:wasm-module textures
+//export add_action
+func addAction(texture uint32, action uint64)
+
+//go:wasm-module textures
+//export get_pixel
+func getPixel(texture uint32, x, y int) Pixel
+
+//go:wasm-module textures
+//export flush_actions
+func flushActions(texture uint32)
+
+//go:wasm-module textures
+//export apply_to
+func applyTo(texture uint32, target uint64)
+
+//go:wasm-module textures
+//export expose
+func expose(texture uint32, name uint64)
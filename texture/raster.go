@@ -0,0 +1,150 @@
+package texture
+
+import (
+	"image"
+	"math"
+	"sort"
+
+	"github.com/oriolus-software/script-go/lmath"
+)
+
+// maxPixelsPerAction keeps a single encoded DrawPixels action well below
+// the size of the memory shared with the host.
+const maxPixelsPerAction = 512
+
+// maxTextureSize bounds the area shapes are rasterized in for textures of
+// unknown size, see Texture.Bounds.
+const maxTextureSize = 1 << 14
+
+// span covers the pixels x0 <= x < x1 of a row.
+type span struct {
+	x0, x1 int
+	color  Color
+}
+
+// raster collects the rows of a shape before it is turned into actions.
+type raster struct {
+	rows map[int][]span
+	// clip is the area of the texture. Shapes only visit the rows inside
+	// it, so far off coordinates do not take long to rasterize.
+	clip image.Rectangle
+}
+
+func newRaster(t Texture) *raster {
+	clip := t.Bounds()
+	if clip.Empty() {
+		clip = image.Rect(0, 0, maxTextureSize, maxTextureSize)
+	}
+
+	return &raster{rows: make(map[int][]span), clip: clip}
+}
+
+// span adds a span, clipping it to the texture.
+func (r *raster) span(y, x0, x1 int, color Color) {
+	x0, x1 = max(x0, r.clip.Min.X), min(x1, r.clip.Max.X)
+	if y < r.clip.Min.Y || y >= r.clip.Max.Y || x1 <= x0 {
+		return
+	}
+
+	r.rows[y] = append(r.rows[y], span{x0, x1, color})
+}
+
+// rowsOf returns the first and last row covering top to bottom that lie
+// inside the texture. The first is greater than the last if there are none.
+func (r *raster) rowsOf(top, bottom float64) (int, int) {
+	top = max(top, float64(r.clip.Min.Y))
+	bottom = min(bottom, float64(r.clip.Max.Y-1))
+	if top > bottom {
+		return 0, -1
+	}
+
+	return int(math.Floor(top)), int(math.Floor(bottom))
+}
+
+func (r *raster) pixel(x, y int, color Color) {
+	r.span(y, x, x+1, color)
+}
+
+// rects merges the spans of each row and then identical spans of
+// consecutive rows into rectangles.
+func (r *raster) rects() []pixelRect {
+	ys := make([]int, 0, len(r.rows))
+	for y := range r.rows {
+		ys = append(ys, y)
+	}
+	sort.Ints(ys)
+
+	var rects []pixelRect
+	open := make(map[span]int)
+
+	for _, y := range ys {
+		next := make(map[span]int)
+		for _, s := range mergeSpans(r.rows[y]) {
+			if i, ok := open[s]; ok && rects[i].y1 == y {
+				rects[i].y1 = y + 1
+				next[s] = i
+				continue
+			}
+
+			next[s] = len(rects)
+			rects = append(rects, pixelRect{x0: s.x0, y0: y, x1: s.x1, y1: y + 1, color: s.color})
+		}
+		open = next
+	}
+
+	return rects
+}
+
+// mergeSpans sorts the spans of a row and joins overlapping or touching
+// spans of the same color.
+func mergeSpans(spans []span) []span {
+	sort.Slice(spans, func(i, j int) bool { return spans[i].x0 < spans[j].x0 })
+
+	merged := spans[:0]
+	for _, s := range spans {
+		if n := len(merged); n > 0 && merged[n-1].color == s.color && s.x0 <= merged[n-1].x1 {
+			if s.x1 > merged[n-1].x1 {
+				merged[n-1].x1 = s.x1
+			}
+			continue
+		}
+
+		merged = append(merged, s)
+	}
+
+	return merged
+}
+
+type pixelRect struct {
+	x0, y0, x1, y1 int
+	color          Color
+}
+
+// draw adds the collected shape to t. Rectangles covering more than one
+// pixel become DrawRect actions, the remaining pixels DrawPixels actions
+// of at most maxPixelsPerAction pixels.
+func (r *raster) draw(t Texture) {
+	var pixels []DrawPixel
+
+	for _, rect := range r.rects() {
+		if rect.x1-rect.x0 == 1 && rect.y1-rect.y0 == 1 {
+			pixels = append(pixels, DrawPixel{
+				Pos:   lmath.UVec2{X: uint(rect.x0), Y: uint(rect.y0)},
+				Color: rect.color,
+			})
+			continue
+		}
+
+		t.DrawRect(
+			lmath.UVec2{X: uint(rect.x0), Y: uint(rect.y0)},
+			lmath.UVec2{X: uint(rect.x1), Y: uint(rect.y1)},
+			rect.color,
+		)
+	}
+
+	for len(pixels) > 0 {
+		n := min(len(pixels), maxPixelsPerAction)
+		t.DrawPixels(pixels[:n])
+		pixels = pixels[n:]
+	}
+}
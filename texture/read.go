@@ -0,0 +1,83 @@
+package texture
+
+import (
+	"image"
+
+	"github.com/oriolus-software/script-go/internal/ffi"
+	"github.com/oriolus-software/script-go/lmath"
+)
+
+// maxPixelsPerRead keeps the host's reply to a single read, 4 bytes per
+// pixel, well below the 32 KB of memory shared with the host. Larger
+// replies would not fit, so bigger regions cannot be read in one call.
+const maxPixelsPerRead = 4096
+
+// ReadRegion returns the colors of the pixels in rect, end exclusive, row
+// by row. Pixels outside of the texture are transparent black. Like
+// GetPixel, it only sees flushed actions.
+//
+// The textures.read_region import it relies on is not part of every host,
+// and a module importing a function the host lacks fails to load. It is
+// only used when building with -tags textures_read_region. Otherwise every
+// pixel is read with GetPixel, which takes one host call per pixel and has
+// no alpha, so all pixels are reported as opaque.
+//
+// With the import, regions of up to 4096 pixels, like a 64x64 area, are
+// read in one host call. Larger regions take one call per tile of that
+// size, since the reply has to fit into the memory shared with the host.
+func (t Texture) ReadRegion(rect lmath.Rectangle) []Color {
+	if rect.End.X <= rect.Start.X || rect.End.Y <= rect.Start.Y {
+		return nil
+	}
+
+	x, y := int(rect.Start.X), int(rect.Start.Y)
+	width, height := int(rect.End.X-rect.Start.X), int(rect.End.Y-rect.Start.Y)
+	colors := make([]Color, width*height)
+
+	if !hasReadRegion {
+		bounds := t.Bounds()
+		for i := range colors {
+			px, py := x+i%width, y+i/width
+			if bounds.Empty() || image.Pt(px, py).In(bounds) {
+				p := t.GetPixel(px, py)
+				colors[i] = Color{R: p.R, G: p.G, B: p.B, A: 255}
+			}
+		}
+
+		return colors
+	}
+
+	tileWidth := min(width, maxPixelsPerRead)
+	tileHeight := max(1, maxPixelsPerRead/tileWidth)
+
+	for ty := 0; ty < height; ty += tileHeight {
+		for tx := 0; tx < width; tx += tileWidth {
+			w, h := min(tileWidth, width-tx), min(tileHeight, height-ty)
+			data := ffi.Deserialize[[]byte](readRegion(uint32(t), x+tx, y+ty, w, h))
+
+			for i := 0; i < w*h && 4*i+3 < len(data); i++ {
+				colors[(ty+i/w)*width+tx+i%w] = Color{
+					R: data[4*i],
+					G: data[4*i+1],
+					B: data[4*i+2],
+					A: data[4*i+3],
+				}
+			}
+		}
+	}
+
+	return colors
+}
+
+// GetColor returns the color of a single pixel including its alpha, see
+// ReadRegion for when the alpha is known.
+func (t Texture) GetColor(x, y int) Color {
+	if x < 0 || y < 0 {
+		return Color{}
+	}
+
+	return t.ReadRegion(lmath.Rectangle{
+		Start: lmath.UVec2{X: uint(x), Y: uint(y)},
+		End:   lmath.UVec2{X: uint(x) + 1, Y: uint(y) + 1},
+	})[0]
+}
@@ -0,0 +1,13 @@
+//go:build wasm && !textures_read_region
+
+package texture
+
+// hasReadRegion reports whether the host provides the read_region import,
+// see ReadRegion.
+const hasReadRegion = false
+
+// readRegion is never called without the import, it only keeps ReadRegion
+// compiling.
+func readRegion(texture uint32, x, y, width, height int) uint64 {
+	panic("texture: read_region is not available")
+}
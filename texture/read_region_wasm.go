@@ -0,0 +1,11 @@
+//go:build wasm && textures_read_region
+
+package texture
+
+// hasReadRegion reports whether the host provides the read_region import,
+// see ReadRegion.
+const hasReadRegion = true
+
+//go:wasm-module textures
+//export read_region
+func readRegion(texture uint32, x, y, width, height int) uint64
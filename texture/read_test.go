@@ -0,0 +1,61 @@
+package texture_test
+
+import (
+	"testing"
+
+	"github.com/oriolus-software/script-go/lmath"
+	"github.com/oriolus-software/script-go/scripttest"
+	"github.com/oriolus-software/script-go/texture"
+)
+
+func TestReadRegion(t *testing.T) {
+	scripttest.New(t)
+
+	translucent := texture.Color{R: 10, G: 20, B: 30, A: 128}
+
+	// Wide enough to be read in several tiles.
+	tex := texture.Create(texture.CreationOptions{Width: 100, Height: 60})
+	tex.Clear(black)
+	tex.DrawRect(lmath.UVec2{X: 90, Y: 50}, lmath.UVec2{X: 100, Y: 60}, translucent)
+	tex.DrawPixels([]texture.DrawPixel{{Pos: lmath.UVec2{X: 3, Y: 2}, Color: white}})
+	tex.Flush()
+
+	colors := tex.ReadRegion(lmath.Rectangle{End: lmath.UVec2{X: 100, Y: 60}})
+	if len(colors) != 6000 {
+		t.Fatalf("got %d colors, want 6000", len(colors))
+	}
+
+	for i, c := range colors {
+		x, y := i%100, i/100
+
+		want := black
+		switch {
+		case x == 3 && y == 2:
+			want = white
+		case x >= 90 && y >= 50:
+			want = translucent
+		}
+
+		if c != want {
+			t.Fatalf("pixel (%d, %d) = %v, want %v", x, y, c, want)
+		}
+	}
+
+	// Pixels outside of the texture are transparent.
+	edge := tex.ReadRegion(lmath.Rectangle{Start: lmath.UVec2{X: 99, Y: 59}, End: lmath.UVec2{X: 101, Y: 60}})
+	if len(edge) != 2 || edge[0] != translucent || edge[1] != (texture.Color{}) {
+		t.Fatalf("got %v", edge)
+	}
+
+	if got := tex.GetColor(95, 55); got != translucent {
+		t.Fatalf("GetColor = %v, want %v", got, translucent)
+	}
+
+	if got := tex.GetPixel(3, 2); got != (texture.Pixel{R: 255, G: 255, B: 255}) {
+		t.Fatalf("GetPixel = %v", got)
+	}
+
+	if got := tex.ReadRegion(lmath.Rectangle{Start: lmath.UVec2{X: 5, Y: 5}, End: lmath.UVec2{X: 5, Y: 9}}); got != nil {
+		t.Fatalf("empty region returned %v", got)
+	}
+}
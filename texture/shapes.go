@@ -0,0 +1,398 @@
+package texture
+
+import (
+	"image"
+	"math"
+	"sort"
+
+	"github.com/oriolus-software/script-go/lmath"
+)
+
+// The shapes below are rasterized by the script and sent as DrawRect and
+// DrawPixels actions. A pixel is drawn when its center lies inside the
+// shape, edges are not anti-aliased.
+
+// DrawLine draws a line from one point to another. Lines up to one pixel
+// thick are drawn with Bresenham's algorithm, thicker lines as a rectangle
+// centered on the line with flat ends.
+func (t Texture) DrawLine(from, to lmath.Vec2, thickness float32, color Color) {
+	r := newRaster(t)
+	r.line(from, to, thickness, color)
+	r.draw(t)
+}
+
+// DrawCircle draws the outline of a circle, centered on radius.
+func (t Texture) DrawCircle(center lmath.Vec2, radius, thickness float32, color Color) {
+	r := newRaster(t)
+	r.ring(center, radius+thickness/2, radius-thickness/2, color)
+	r.draw(t)
+}
+
+// FillCircle draws a filled circle.
+func (t Texture) FillCircle(center lmath.Vec2, radius float32, color Color) {
+	r := newRaster(t)
+	r.ring(center, radius, -1, color)
+	r.draw(t)
+}
+
+// DrawPolygon draws the closed outline through points.
+func (t Texture) DrawPolygon(points []lmath.Vec2, thickness float32, color Color) {
+	r := newRaster(t)
+	for i, p := range points {
+		r.line(p, points[(i+1)%len(points)], thickness, color)
+
+		// Round joins close the gaps between thick segments.
+		if thickness > 1 {
+			r.ring(p, thickness/2, -1, color)
+		}
+	}
+	r.draw(t)
+}
+
+// FillPolygon fills the polygon through points using the even-odd rule.
+func (t Texture) FillPolygon(points []lmath.Vec2, color Color) {
+	r := newRaster(t)
+	r.polygon(points, color)
+	r.draw(t)
+}
+
+// DrawRoundedRect draws a filled rectangle like DrawRect with corners
+// rounded by radius.
+func (t Texture) DrawRoundedRect(start, end lmath.UVec2, radius float32, color Color) {
+	x0, y0, x1, y1 := int(start.X), int(start.Y), int(end.X), int(end.Y)
+
+	maxRadius := float32(min(x1-x0, y1-y0)) / 2
+	radius = max(0, min(radius, maxRadius))
+
+	r := newRaster(t)
+	top, bottom := r.rowsOf(float64(y0), float64(y1-1))
+	for y := top; y <= bottom; y++ {
+		// Distance of the pixel center from the nearest horizontal edge.
+		dy := float64(min(float32(y-y0)+0.5, float32(y1-y)-0.5))
+
+		var inset float64
+		if rad := float64(radius); dy < rad {
+			inset = rad - math.Sqrt(rad*rad-(rad-dy)*(rad-dy))
+		}
+
+		left, right := spanOf(float64(x0)+inset, float64(x1)-inset)
+		r.span(y, left, right, color)
+	}
+	r.draw(t)
+}
+
+// ColorStop is a color at a position between 0 and 1 of a gradient.
+type ColorStop struct {
+	Offset float32
+	Color  Color
+}
+
+// LinearGradient blends colors along the line from From to To. Stops must
+// be sorted by offset, pixels before the first or after the last stop get
+// its color.
+type LinearGradient struct {
+	From, To lmath.Vec2
+	Stops    []ColorStop
+}
+
+// DrawGradient fills the rectangle from start to end, end exclusive, with a
+// linear gradient. Horizontal and vertical gradients are drawn as one
+// rectangle per column or row of equal color, others pixel by pixel.
+func (t Texture) DrawGradient(start, end lmath.UVec2, gradient LinearGradient) {
+	if len(gradient.Stops) == 0 {
+		return
+	}
+
+	r := newRaster(t)
+	area := image.Rect(int(start.X), int(start.Y), int(end.X), int(end.Y)).Intersect(r.clip)
+	if area.Empty() {
+		return
+	}
+
+	dx := float64(gradient.To.X - gradient.From.X)
+	dy := float64(gradient.To.Y - gradient.From.Y)
+	length := dx*dx + dy*dy
+
+	colorAt := func(x, y int) Color {
+		var offset float64
+		if length > 0 {
+			px := float64(x) + 0.5 - float64(gradient.From.X)
+			py := float64(y) + 0.5 - float64(gradient.From.Y)
+			offset = (px*dx + py*dy) / length
+		}
+
+		return gradient.at(float32(offset))
+	}
+
+	switch {
+	case dy == 0:
+		bands(area.Min.X, area.Max.X, func(x int) Color {
+			return colorAt(x, area.Min.Y)
+		}, func(x0, x1 int, color Color) {
+			t.DrawRect(
+				lmath.UVec2{X: uint(x0), Y: uint(area.Min.Y)},
+				lmath.UVec2{X: uint(x1), Y: uint(area.Max.Y)},
+				color,
+			)
+		})
+	case dx == 0:
+		bands(area.Min.Y, area.Max.Y, func(y int) Color {
+			return colorAt(area.Min.X, y)
+		}, func(y0, y1 int, color Color) {
+			t.DrawRect(
+				lmath.UVec2{X: uint(area.Min.X), Y: uint(y0)},
+				lmath.UVec2{X: uint(area.Max.X), Y: uint(y1)},
+				color,
+			)
+		})
+	default:
+		for y := area.Min.Y; y < area.Max.Y; y++ {
+			for x := area.Min.X; x < area.Max.X; x++ {
+				r.pixel(x, y, colorAt(x, y))
+			}
+		}
+		r.draw(t)
+	}
+}
+
+// bands calls draw for every run of equal colors between from and to.
+func bands(from, to int, colorAt func(int) Color, draw func(from, to int, color Color)) {
+	start, color := from, colorAt(from)
+	for i := from + 1; i <= to; i++ {
+		var next Color
+		if i < to {
+			next = colorAt(i)
+			if next == color {
+				continue
+			}
+		}
+
+		draw(start, i, color)
+		start, color = i, next
+	}
+}
+
+func (g LinearGradient) at(offset float32) Color {
+	stops := g.Stops
+	if offset <= stops[0].Offset {
+		return stops[0].Color
+	}
+
+	for i := 1; i < len(stops); i++ {
+		if offset <= stops[i].Offset {
+			a, b := stops[i-1], stops[i]
+			f := (offset - a.Offset) / (b.Offset - a.Offset)
+			return lerpColor(a.Color, b.Color, f)
+		}
+	}
+
+	return stops[len(stops)-1].Color
+}
+
+func lerpColor(a, b Color, f float32) Color {
+	lerp := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(float32(a) + (float32(b)-float32(a))*f)))
+	}
+
+	return Color{R: lerp(a.R, b.R), G: lerp(a.G, b.G), B: lerp(a.B, b.B), A: lerp(a.A, b.A)}
+}
+
+func (r *raster) line(from, to lmath.Vec2, thickness float32, color Color) {
+	if thickness > 1 {
+		dx, dy := float64(to.X-from.X), float64(to.Y-from.Y)
+		length := math.Hypot(dx, dy)
+		if length == 0 {
+			r.ring(from, thickness/2, -1, color)
+			return
+		}
+
+		// Offset perpendicular to the line by half the thickness.
+		nx := float32(-dy / length * float64(thickness) / 2)
+		ny := float32(dx / length * float64(thickness) / 2)
+
+		r.polygon([]lmath.Vec2{
+			{X: from.X + nx, Y: from.Y + ny},
+			{X: to.X + nx, Y: to.Y + ny},
+			{X: to.X - nx, Y: to.Y - ny},
+			{X: from.X - nx, Y: from.Y - ny},
+		}, color)
+		return
+	}
+
+	// Only the part of the line near the texture is walked, so far off end
+	// points do not take long. Lines inside the texture are not changed.
+	area := r.clip.Inset(-1)
+	if !inside(from, area) || !inside(to, area) {
+		var ok bool
+		if from, to, ok = clipLine(from, to, area); !ok {
+			return
+		}
+	}
+
+	x0, y0 := floor(from.X), floor(from.Y)
+	x1, y1 := floor(to.X), floor(to.Y)
+
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := sign(x1-x0), sign(y1-y0)
+	e := dx + dy
+
+	for {
+		r.pixel(x0, y0, color)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func inside(p lmath.Vec2, rect image.Rectangle) bool {
+	return p.X >= float32(rect.Min.X) && p.X < float32(rect.Max.X) &&
+		p.Y >= float32(rect.Min.Y) && p.Y < float32(rect.Max.Y)
+}
+
+// clipLine clips the line from a to b to rect with the Liang-Barsky
+// algorithm. It reports false if the line misses rect.
+func clipLine(a, b lmath.Vec2, rect image.Rectangle) (lmath.Vec2, lmath.Vec2, bool) {
+	x, y := float64(a.X), float64(a.Y)
+	dx, dy := float64(b.X)-x, float64(b.Y)-y
+
+	t0, t1 := 0.0, 1.0
+	for _, edge := range [4][2]float64{
+		{-dx, x - float64(rect.Min.X)},
+		{dx, float64(rect.Max.X) - x},
+		{-dy, y - float64(rect.Min.Y)},
+		{dy, float64(rect.Max.Y) - y},
+	} {
+		p, q := edge[0], edge[1]
+		if p == 0 {
+			if q < 0 {
+				return a, b, false
+			}
+			continue
+		}
+
+		if t := q / p; p < 0 {
+			t0 = max(t0, t)
+		} else {
+			t1 = min(t1, t)
+		}
+	}
+
+	if t0 > t1 {
+		return a, b, false
+	}
+
+	return lmath.Vec2{X: float32(x + t0*dx), Y: float32(y + t0*dy)},
+		lmath.Vec2{X: float32(x + t1*dx), Y: float32(y + t1*dy)}, true
+}
+
+// ring adds the pixels whose centers lie between the inner and outer
+// radius. A negative inner radius fills the circle.
+func (r *raster) ring(center lmath.Vec2, outer, inner float32, color Color) {
+	if outer <= 0 {
+		return
+	}
+
+	cx, cy := float64(center.X), float64(center.Y)
+	ro, ri := float64(outer), float64(inner)
+
+	top, bottom := r.rowsOf(cy-ro, cy+ro)
+	for y := top; y <= bottom; y++ {
+		dy := float64(y) + 0.5 - cy
+		if dy*dy > ro*ro {
+			continue
+		}
+
+		// Pixels x with centers x+0.5 in [cx-w, cx+w].
+		w := math.Sqrt(ro*ro - dy*dy)
+		left, right := spanOf(cx-w, cx+w)
+
+		if ri <= 0 || dy*dy >= ri*ri {
+			r.span(y, left, right, color)
+			continue
+		}
+
+		wi := math.Sqrt(ri*ri - dy*dy)
+		innerLeft, innerRight := spanOf(cx-wi, cx+wi)
+		r.span(y, left, innerLeft, color)
+		r.span(y, innerRight, right, color)
+	}
+}
+
+// polygon fills points with the even-odd rule, sampling pixel centers.
+func (r *raster) polygon(points []lmath.Vec2, color Color) {
+	if len(points) < 3 {
+		return
+	}
+
+	minY, maxY := points[0].Y, points[0].Y
+	for _, p := range points {
+		minY, maxY = min(minY, p.Y), max(maxY, p.Y)
+	}
+
+	var xs []float64
+	top, bottom := r.rowsOf(float64(minY), float64(maxY))
+	for y := top; y <= bottom; y++ {
+		sy := float64(y) + 0.5
+
+		xs = xs[:0]
+		for i, a := range points {
+			b := points[(i+1)%len(points)]
+			ay, by := float64(a.Y), float64(b.Y)
+			if (ay <= sy) == (by <= sy) {
+				continue
+			}
+
+			f := (sy - ay) / (by - ay)
+			xs = append(xs, float64(a.X)+f*float64(b.X-a.X))
+		}
+		sort.Float64s(xs)
+
+		for i := 0; i+1 < len(xs); i += 2 {
+			left, right := spanOf(xs[i], xs[i+1])
+			r.span(y, left, right, color)
+		}
+	}
+}
+
+// spanOf returns the pixels x0 <= x < x1 whose centers lie in [from, to].
+// Far off coordinates are clamped so they convert to int safely, the span
+// is clipped to the texture later on.
+func spanOf(from, to float64) (int, int) {
+	const limit = 1 << 30
+	from, to = max(from, -limit), min(to, limit)
+	return int(math.Ceil(from - 0.5)), int(math.Floor(to-0.5)) + 1
+}
+
+func floor(v float32) int {
+	return int(math.Floor(float64(v)))
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
+
+func sign(v int) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,240 @@
+package texture_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/oriolus-software/script-go/lmath"
+	"github.com/oriolus-software/script-go/scripttest"
+	"github.com/oriolus-software/script-go/texture"
+)
+
+var (
+	black = texture.Color{A: 255}
+	white = texture.Color{R: 255, G: 255, B: 255, A: 255}
+)
+
+// render draws on a fresh texture and returns its pixels as text, '#' for
+// white and '.' for anything else.
+func render(t *testing.T, w, h int, draw func(texture.Texture)) (string, *scripttest.Canvas) {
+	t.Helper()
+	rt := scripttest.New(t)
+
+	tex := texture.Create(texture.CreationOptions{Width: w, Height: h})
+	tex.Clear(black)
+	draw(tex)
+	tex.Flush()
+
+	canvas := rt.Texture(tex)
+
+	var b strings.Builder
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if texture.Color(canvas.Pixel(x, y)) == white {
+				b.WriteByte('#')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.String(), canvas
+}
+
+func expectPixels(t *testing.T, got, want string) {
+	t.Helper()
+
+	want = strings.TrimPrefix(want, "\n")
+	if got != want {
+		t.Fatalf("got\n%swant\n%s", got, want)
+	}
+}
+
+func TestDrawLine(t *testing.T) {
+	got, _ := render(t, 6, 4, func(tex texture.Texture) {
+		tex.DrawLine(lmath.Vec2{X: 0.5, Y: 0.5}, lmath.Vec2{X: 5.5, Y: 3.5}, 1, white)
+	})
+
+	expectPixels(t, got, `
+#.....
+.##...
+...##.
+.....#
+`)
+}
+
+func TestDrawThickLine(t *testing.T) {
+	got, _ := render(t, 6, 5, func(tex texture.Texture) {
+		tex.DrawLine(lmath.Vec2{X: 1, Y: 2.5}, lmath.Vec2{X: 5, Y: 2.5}, 3, white)
+	})
+
+	expectPixels(t, got, `
+......
+.####.
+.####.
+.####.
+......
+`)
+}
+
+func TestCircles(t *testing.T) {
+	got, _ := render(t, 7, 7, func(tex texture.Texture) {
+		tex.FillCircle(lmath.Vec2{X: 3.5, Y: 3.5}, 3, white)
+	})
+
+	expectPixels(t, got, `
+...#...
+.#####.
+.#####.
+#######
+.#####.
+.#####.
+...#...
+`)
+
+	got, _ = render(t, 7, 7, func(tex texture.Texture) {
+		tex.DrawCircle(lmath.Vec2{X: 3.5, Y: 3.5}, 3, 1, white)
+	})
+
+	expectPixels(t, got, `
+..###..
+.#...#.
+#.....#
+#.....#
+#.....#
+.#...#.
+..###..
+`)
+}
+
+func TestPolygons(t *testing.T) {
+	triangle := []lmath.Vec2{{X: 0, Y: 0}, {X: 6, Y: 0}, {X: 0, Y: 6}}
+
+	got, _ := render(t, 6, 6, func(tex texture.Texture) {
+		tex.FillPolygon(triangle, white)
+	})
+
+	expectPixels(t, got, `
+######
+#####.
+####..
+###...
+##....
+#.....
+`)
+
+	square := []lmath.Vec2{{X: 0.5, Y: 0.5}, {X: 4.5, Y: 0.5}, {X: 4.5, Y: 4.5}, {X: 0.5, Y: 4.5}}
+
+	got, _ = render(t, 5, 5, func(tex texture.Texture) {
+		tex.DrawPolygon(square, 1, white)
+	})
+
+	expectPixels(t, got, `
+#####
+#...#
+#...#
+#...#
+#####
+`)
+}
+
+func TestDrawRoundedRect(t *testing.T) {
+	got, canvas := render(t, 8, 6, func(tex texture.Texture) {
+		tex.DrawRoundedRect(lmath.UVec2{X: 1, Y: 0}, lmath.UVec2{X: 7, Y: 6}, 2, white)
+	})
+
+	expectPixels(t, got, `
+..####..
+.######.
+.######.
+.######.
+.######.
+..####..
+`)
+
+	// Clear, the top and bottom row share one rect, the middle rows are
+	// merged into one rect.
+	if len(canvas.Actions) != 4 {
+		t.Fatalf("got %d actions, want 4", len(canvas.Actions))
+	}
+}
+
+func TestDrawGradient(t *testing.T) {
+	red := texture.Color{R: 255, A: 255}
+	blue := texture.Color{B: 255, A: 255}
+
+	_, canvas := render(t, 4, 3, func(tex texture.Texture) {
+		tex.DrawGradient(lmath.UVec2{}, lmath.UVec2{X: 4, Y: 3}, texture.LinearGradient{
+			From:  lmath.Vec2{X: 0.5},
+			To:    lmath.Vec2{X: 3.5},
+			Stops: []texture.ColorStop{{Offset: 0, Color: red}, {Offset: 1, Color: blue}},
+		})
+	})
+
+	for y := 0; y < 3; y++ {
+		if got := texture.Color(canvas.Pixel(0, y)); got != red {
+			t.Fatalf("pixel (0, %d) = %v, want red", y, got)
+		}
+
+		if got := texture.Color(canvas.Pixel(3, y)); got != blue {
+			t.Fatalf("pixel (3, %d) = %v, want blue", y, got)
+		}
+	}
+
+	if got := texture.Color(canvas.Pixel(1, 0)); got != (texture.Color{R: 170, B: 85, A: 255}) {
+		t.Fatalf("pixel (1, 0) = %v", got)
+	}
+
+	// Every column is one rect.
+	if len(canvas.Actions) != 5 {
+		t.Fatalf("got %d actions, want 5", len(canvas.Actions))
+	}
+}
+
+func TestShapesClipToTexture(t *testing.T) {
+	got, canvas := render(t, 4, 3, func(tex texture.Texture) {
+		tex.DrawLine(lmath.Vec2{X: -1e9, Y: -1e9}, lmath.Vec2{X: 1e9, Y: 1e9}, 1, white)
+		tex.DrawLine(lmath.Vec2{X: -1e9, Y: 2.5}, lmath.Vec2{X: 1e9, Y: 2.5}, 1, white)
+		tex.FillCircle(lmath.Vec2{X: 1e9, Y: 1e9}, 1e6, white)
+		tex.FillPolygon([]lmath.Vec2{{X: 1e9}, {X: 1e9 + 1, Y: 1e9}, {Y: 1e9}}, white)
+	})
+
+	expectPixels(t, got, `
+#...
+.#..
+####
+`)
+
+	// The clear, one rect for the bottom row and the pixels of the diagonal.
+	if len(canvas.Actions) != 3 {
+		t.Fatalf("got %d actions, want 3", len(canvas.Actions))
+	}
+}
+
+func TestDrawVerticalGradient(t *testing.T) {
+	red := texture.Color{R: 255, A: 255}
+	blue := texture.Color{B: 255, A: 255}
+
+	_, canvas := render(t, 3, 4, func(tex texture.Texture) {
+		tex.DrawGradient(lmath.UVec2{}, lmath.UVec2{X: 100, Y: 100}, texture.LinearGradient{
+			From:  lmath.Vec2{Y: 1},
+			To:    lmath.Vec2{Y: 3},
+			Stops: []texture.ColorStop{{Offset: 0, Color: red}, {Offset: 1, Color: blue}},
+		})
+	})
+
+	want := []texture.Color{red, {R: 191, B: 64, A: 255}, {R: 64, B: 191, A: 255}, blue}
+	for y, color := range want {
+		for x := 0; x < 3; x++ {
+			if got := texture.Color(canvas.Pixel(x, y)); got != color {
+				t.Fatalf("pixel (%d, %d) = %v, want %v", x, y, got, color)
+			}
+		}
+	}
+
+	// The clear and one rect per row.
+	if len(canvas.Actions) != 5 {
+		t.Fatalf("got %d actions, want 5", len(canvas.Actions))
+	}
+}
@@ -1,6 +1,8 @@
 package texture
 
 import (
+	"image"
+
 	"github.com/oriolus-software/script-go/assets"
 	"github.com/oriolus-software/script-go/internal/ffi"
 	"github.com/oriolus-software/script-go/lmath"
@@ -28,13 +30,19 @@ type Color struct {
 }
 
 func Create(opts CreationOptions) Texture {
-	return Texture(create(ffi.Serialize(opts).ToPacked()))
+	t := Texture(create(ffi.Serialize(opts).ToPacked()))
+	sizes[t] = image.Pt(opts.Width, opts.Height)
+	return t
 }
 
 func (t Texture) Dispose() {
+	t.forget()
+	delete(sizes, t)
 	dispose(uint32(t))
 }
 
+// GetPixel returns the color of a single pixel without its alpha. GetColor
+// and ReadRegion include the alpha.
 func (t Texture) GetPixel(x, y int) Pixel {
 	return getPixel(uint32(t), x, y)
 }
@@ -121,17 +129,35 @@ func (t Texture) DrawText(options *DrawTextOptions) {
 }
 
 func (t Texture) DrawScriptTexture(src Texture, options DrawTextureOptions) {
+	// The host names the rectangles like the Go fields.
+	type drawTextureOptions struct {
+		SourceRect lmath.Rectangle `msgpack:"SourceRect"`
+		TargetRect lmath.Rectangle `msgpack:"TargetRect"`
+		AlphaMode  any             `msgpack:"alpha_mode"`
+	}
+
 	type drawScriptTexture struct {
 		Handle  uint32             `msgpack:"handle"`
-		Options DrawTextureOptions `msgpack:"options"`
+		Options drawTextureOptions `msgpack:"options"`
+	}
+
+	var alphaMode any
+	if options.AlphaMode != nil {
+		alphaMode = options.AlphaMode.alphaValue()
+	} else {
+		alphaMode = "Opaque"
 	}
 
 	t.addAction(struct {
 		DrawScriptTexture drawScriptTexture
 	}{
 		DrawScriptTexture: drawScriptTexture{
-			Handle:  uint32(src),
-			Options: options,
+			Handle: uint32(src),
+			Options: drawTextureOptions{
+				SourceRect: options.SourceRect,
+				TargetRect: options.TargetRect,
+				AlphaMode:  alphaMode,
+			},
 		},
 	})
 }
@@ -141,47 +167,59 @@ func (t Texture) Expose(name string) {
 }
 
 func (t Texture) Flush() {
+	t.forget()
 	flushActions(uint32(t))
 }
 
 func (t Texture) addAction(action any) {
+	if _, ok := pending[t]; !ok {
+		pending[t] = struct{}{}
+		pendingOrder = append(pendingOrder, t)
+	}
+
 	addAction(uint32(t), ffi.Serialize(action).ToPacked())
 }
 
+func (t Texture) forget() {
+	if _, ok := pending[t]; !ok {
+		return
+	}
+
+	delete(pending, t)
+	for i, other := range pendingOrder {
+		if other == t {
+			pendingOrder = append(pendingOrder[:i], pendingOrder[i+1:]...)
+			break
+		}
+	}
+}
+
+// Textures with actions that were not flushed yet, in the order of their
+// first action.
+var (
+	pending      = make(map[Texture]struct{})
+	pendingOrder []Texture
+)
+
+// Flush flushes every texture that has pending actions.
+func Flush() {
+	textures := pendingOrder
+	pending = make(map[Texture]struct{})
+	pendingOrder = nil
+
+	for _, t := range textures {
+		flushActions(uint32(t))
+	}
+}
+
 type DrawTextureOptions struct {
 	SourceRect lmath.Rectangle
 	TargetRect lmath.Rectangle
+	// AlphaMode defaults to AlphaOpaque, like the one of DrawTextOptions.
+	AlphaMode AlphaMode
 }
 
 type DrawPixel struct {
 	Pos   lmath.UVec2 `msgpack:"pos"`
 	Color Color       `msgpack:"color"`
 }
-
-//go:wasm-module textures
-//export create
-func create(opts uint64) uint32
-
-//go:wasm-module textures
-//export dispose
-func dispose(texture uint32)
-
-//go:wasm-module textures
-//export add_action
-func addAction(texture uint32, action uint64)
-
-//go:wasm-module textures
-//export get_pixel
-func getPixel(texture uint32, x, y int) Pixel
-
-//go:wasm-module textures
-//export flush_actions
-func flushActions(texture uint32)
-
-//go:wasm-module textures
-//export apply_to
-func applyTo(texture uint32, target uint64)
-
-//go:wasm-module textures
-//export expose
-func expose(texture uint32, name uint64)
@@ -0,0 +1,19 @@
+//go:build !wasm
+
+package time
+
+import (
+	"github.com/oriolus-software/script-go/internal/host"
+)
+
+func Delta64() float64 {
+	return host.Current.Time.Delta
+}
+
+func TicksAlive() uint64 {
+	return host.Current.Time.Ticks
+}
+
+func gameTime() int64 {
+	return host.Current.Time.GameTime
+}
@@ -0,0 +1,162 @@
+package vars
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/oriolus-software/script-go/assets"
+)
+
+var contentIdType = reflect.TypeOf(assets.ContentId{})
+
+var bindings []*Binding
+
+// Binding ties the `var:"name"` tagged fields of a struct to host variables.
+type Binding struct {
+	value  reflect.Value
+	fields []boundField
+}
+
+type boundField struct {
+	name  string
+	index int
+	// last is the value the host is known to have, used to skip unchanged
+	// fields on Flush.
+	last any
+}
+
+// Bind binds the fields of the struct pointed to by ptr that are tagged with
+// `var:"name"` to the host variable name. The fields are pulled immediately,
+// then again by every call to Pull, and changed fields are written back by
+// Flush. Fields may have any type accepted by Set.
+func Bind(ptr any) *Binding {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("bind target must be a non-nil struct pointer, got %T", ptr))
+	}
+
+	b := &Binding{value: rv.Elem()}
+	rt := b.value.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name := field.Tag.Get("var")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		if !field.IsExported() {
+			panic(fmt.Sprintf("bound field %s.%s must be exported", rt.Name(), field.Name))
+		}
+
+		if !supported(field.Type) {
+			panic(fmt.Sprintf("unsupported type for bound field %s.%s: %s", rt.Name(), field.Name, field.Type))
+		}
+
+		b.fields = append(b.fields, boundField{name: name, index: i})
+	}
+
+	bindings = append(bindings, b)
+	b.Pull()
+
+	return b
+}
+
+// Unbind stops the package level Pull and Flush from updating the binding.
+func (b *Binding) Unbind() {
+	for i, other := range bindings {
+		if other == b {
+			bindings = append(bindings[:i], bindings[i+1:]...)
+			return
+		}
+	}
+}
+
+// Pull reads every bound variable from the host into the struct.
+func (b *Binding) Pull() {
+	for i := range b.fields {
+		f := &b.fields[i]
+		rv := b.value.Field(f.index)
+
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			rv.SetInt(GetI64(f.name))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			rv.SetUint(uint64(GetI64(f.name)))
+		case reflect.Float32, reflect.Float64:
+			rv.SetFloat(GetF64(f.name))
+		case reflect.Bool:
+			rv.SetBool(GetBool(f.name))
+		case reflect.String:
+			rv.SetString(GetString(f.name))
+		case reflect.Struct:
+			rv.Set(reflect.ValueOf(GetContentId(f.name)))
+		}
+
+		f.last = rv.Interface()
+	}
+}
+
+// Flush writes every bound field that changed since the last Pull or Flush
+// back to the host.
+func (b *Binding) Flush() {
+	for i := range b.fields {
+		f := &b.fields[i]
+		rv := b.value.Field(f.index)
+
+		current := rv.Interface()
+		if current == f.last {
+			continue
+		}
+
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			SetI64(f.name, rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			SetI64(f.name, int64(rv.Uint()))
+		case reflect.Float32, reflect.Float64:
+			SetF64(f.name, rv.Float())
+		case reflect.Bool:
+			SetBool(f.name, rv.Bool())
+		case reflect.String:
+			SetString(f.name, rv.String())
+		case reflect.Struct:
+			SetContentId(f.name, current.(assets.ContentId))
+		}
+
+		f.last = current
+	}
+}
+
+// Pull pulls every binding created by Bind and every handle created by New.
+// It is meant to run at the start of a tick.
+func Pull() {
+	for _, b := range bindings {
+		b.Pull()
+	}
+
+	for _, h := range handles {
+		h.pull()
+	}
+}
+
+// Flush flushes every binding created by Bind. It is meant to run at the end
+// of a tick.
+func Flush() {
+	for _, b := range bindings {
+		b.Flush()
+	}
+}
+
+func supported(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Bool, reflect.String:
+		return true
+	case reflect.Struct:
+		return t == contentIdType
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+package vars_test
+
+import (
+	"testing"
+
+	"github.com/oriolus-software/script-go/assets"
+	"github.com/oriolus-software/script-go/scripttest"
+	"github.com/oriolus-software/script-go/vars"
+)
+
+type cockpit struct {
+	Throttle float64          `var:"throttle"`
+	Gear     uint8            `var:"gear"`
+	Doors    bool             `var:"doors_open"`
+	Route    string           `var:"route"`
+	Sign     assets.ContentId `var:"sign"`
+	Ignored  int
+}
+
+func TestBind(t *testing.T) {
+	rt := scripttest.New(t)
+	rt.SetVar("throttle", 0.25)
+	rt.SetVar("gear", 3)
+	rt.SetVar("sign", assets.ContentId{UserId: 1, SubId: 2})
+
+	var c cockpit
+	b := vars.Bind(&c)
+	defer b.Unbind()
+
+	if c.Throttle != 0.25 || c.Gear != 3 || c.Sign != (assets.ContentId{UserId: 1, SubId: 2}) {
+		t.Fatalf("unexpected pulled values %+v", c)
+	}
+
+	c.Route = "12"
+	c.Doors = true
+	rt.SetVar("throttle", 0.75)
+	vars.Flush()
+
+	if got := rt.Var("route"); got != "12" {
+		t.Fatalf("expected route to be flushed, got %v", got)
+	}
+
+	if got := rt.Var("doors_open"); got != true {
+		t.Fatalf("expected doors_open to be flushed, got %v", got)
+	}
+
+	if got := rt.Var("throttle"); got != 0.75 {
+		t.Fatalf("unchanged field must not be flushed, got %v", got)
+	}
+
+	vars.Pull()
+	if c.Throttle != 0.75 {
+		t.Fatalf("expected throttle 0.75 after pull, got %v", c.Throttle)
+	}
+}
+
+func TestBindUnsupported(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for unsupported field type")
+		}
+	}()
+
+	vars.Bind(&struct {
+		Values []int `var:"values"`
+	}{})
+}
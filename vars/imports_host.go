@@ -0,0 +1,55 @@
+//go:build !wasm
+
+package vars
+
+import (
+	"github.com/oriolus-software/script-go/internal/ffi"
+	"github.com/oriolus-software/script-go/internal/host"
+)
+
+func get_i64(name uint64) int64 {
+	return host.Current.GetI64(ffi.Deserialize[string](name))
+}
+
+func set_i64(name uint64, value int64) {
+	host.Current.SetI64(ffi.Deserialize[string](name), value)
+}
+
+func get_f64(name uint64) float64 {
+	return host.Current.GetF64(ffi.Deserialize[string](name))
+}
+
+func set_f64(name uint64, value float64) {
+	host.Current.SetF64(ffi.Deserialize[string](name), value)
+}
+
+func get_bool(name uint64) bool {
+	return host.Current.GetBool(ffi.Deserialize[string](name))
+}
+
+func set_bool(name uint64, value bool) {
+	host.Current.SetBool(ffi.Deserialize[string](name), value)
+}
+
+func get_string(name uint64) string {
+	return host.Current.GetString(ffi.Deserialize[string](name))
+}
+
+func set_string(name uint64, value uint64) {
+	host.Current.SetString(ffi.Deserialize[string](name), ffi.Deserialize[string](value))
+}
+
+func get_content_id(name uint64) uint64 {
+	return ffi.Serialize(host.Current.GetContentId(ffi.Deserialize[string](name))).ToPacked()
+}
+
+func set_content_id(name uint64, value uint64) {
+	host.Current.SetContentId(ffi.Deserialize[string](name), ffi.Deserialize[host.ContentId](value))
+}
+
+func init() {
+	host.OnReset(func() {
+		handles = nil
+		bindings = nil
+	})
+}
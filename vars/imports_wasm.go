@@ -0,0 +1,43 @@
+//go:build wasm
+
+package vars
+
+//go:wasm-module var
+//export get_i64
+func get_i64(name uint64) int64
+
+//go:wasm-module var
+//export set_i64
+func set_i64(name uint64, value int64)
+
+//go:wasm-module var
+//export get_f64
+func get_f64(name uint64) float64
+
+//go:wasm-module var
+//export set_f64
+func set_f64(name uint64, value float64)
+
+//go:wasm-module var
+//export get_bool
+func get_bool(name uint64) bool
+
+//go:wasm-module var
+//export set_bool
+func set_bool(name uint64, value bool)
+
+//go:wasm-module var
+//export get_string
+func get_string(name uint64) string
+
+//go:wasm-module var
+//export set_string
+func set_string(name uint64, value uint64)
+
+//go:wasm-module var
+//export get_content_id
+func get_content_id(name uint64) uint64
+
+//go:wasm-module var
+//export set_content_id
+func set_content_id(name uint64, value uint64)
@@ -0,0 +1,106 @@
+package vars
+
+import (
+	"github.com/oriolus-software/script-go/assets"
+	"github.com/oriolus-software/script-go/internal/ffi"
+)
+
+// Value lists the types a host variable can hold.
+type Value interface {
+	int64 | float64 | bool | string | assets.ContentId
+}
+
+// Var is a typed handle to a host variable. The serialized name is cached
+// so accessing the variable does not marshal the name again.
+type Var[T Value] struct {
+	name     string
+	packed   ffi.Prepared
+	current  T
+	previous T
+}
+
+var handles []interface{ pull() }
+
+// New returns a handle to the host variable name. Like bindings, handles
+// are updated by Pull, which decides what Changed and Previous report, until
+// they are released.
+func New[T Value](name string) *Var[T] {
+	v := &Var[T]{
+		name:   name,
+		packed: ffi.Prepare(name),
+	}
+
+	v.current = v.Get()
+	v.previous = v.current
+	handles = append(handles, v)
+
+	return v
+}
+
+// Release stops the package level Pull from updating the handle, so it can
+// be garbage collected. Get and Set keep working, Changed and Previous keep
+// reporting the last pulled values.
+func (v *Var[T]) Release() {
+	for i, other := range handles {
+		if other == v {
+			handles = append(handles[:i], handles[i+1:]...)
+			return
+		}
+	}
+}
+
+func (v *Var[T]) Name() string {
+	return v.name
+}
+
+// Get reads the current value from the host.
+func (v *Var[T]) Get() T {
+	var value T
+
+	switch p := any(&value).(type) {
+	case *int64:
+		*p = get_i64(v.packed.ToPacked())
+	case *float64:
+		*p = get_f64(v.packed.ToPacked())
+	case *bool:
+		*p = get_bool(v.packed.ToPacked())
+	case *string:
+		*p = get_string(v.packed.ToPacked())
+	case *assets.ContentId:
+		*p = ffi.Deserialize[assets.ContentId](get_content_id(v.packed.ToPacked()))
+	}
+
+	return value
+}
+
+// Set writes value to the host.
+func (v *Var[T]) Set(value T) {
+	switch value := any(value).(type) {
+	case int64:
+		set_i64(v.packed.ToPacked(), value)
+	case float64:
+		set_f64(v.packed.ToPacked(), value)
+	case bool:
+		set_bool(v.packed.ToPacked(), value)
+	case string:
+		set_string(v.packed.ToPacked(), ffi.Serialize(value).ToPacked())
+	case assets.ContentId:
+		set_content_id(v.packed.ToPacked(), ffi.Serialize(value).ToPacked())
+	}
+}
+
+// Previous returns the value the variable had at the start of the last tick.
+func (v *Var[T]) Previous() T {
+	return v.previous
+}
+
+// Changed reports whether the value at the start of this tick differs from
+// the one at the start of the last tick.
+func (v *Var[T]) Changed() bool {
+	return v.current != v.previous
+}
+
+func (v *Var[T]) pull() {
+	v.previous = v.current
+	v.current = v.Get()
+}
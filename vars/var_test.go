@@ -0,0 +1,59 @@
+package vars_test
+
+import (
+	"testing"
+
+	"github.com/oriolus-software/script-go/scripttest"
+	"github.com/oriolus-software/script-go/vars"
+)
+
+func TestVar(t *testing.T) {
+	rt := scripttest.New(t)
+	rt.SetVar("throttle", 0.5)
+
+	throttle := vars.New[float64]("throttle")
+	if throttle.Get() != 0.5 || throttle.Changed() {
+		t.Fatalf("unexpected initial state %v, changed %v", throttle.Get(), throttle.Changed())
+	}
+
+	throttle.Set(0.8)
+	if got := rt.Var("throttle"); got != 0.8 {
+		t.Fatalf("expected host value 0.8, got %v", got)
+	}
+
+	vars.Pull()
+	if !throttle.Changed() || throttle.Previous() != 0.5 {
+		t.Fatalf("expected change from 0.5, got changed %v previous %v", throttle.Changed(), throttle.Previous())
+	}
+
+	vars.Pull()
+	if throttle.Changed() || throttle.Previous() != 0.8 {
+		t.Fatalf("expected no change, got changed %v previous %v", throttle.Changed(), throttle.Previous())
+	}
+
+	route := vars.New[string]("route")
+	route.Set("12")
+	if got := route.Get(); got != "12" {
+		t.Fatalf("expected route 12, got %q", got)
+	}
+}
+
+func TestVarRelease(t *testing.T) {
+	rt := scripttest.New(t)
+	rt.SetVar("throttle", 0.5)
+
+	throttle := vars.New[float64]("throttle")
+	throttle.Release()
+	throttle.Release()
+
+	rt.SetVar("throttle", 0.8)
+	vars.Pull()
+
+	if throttle.Changed() || throttle.Previous() != 0.5 {
+		t.Fatalf("released handle was pulled, changed %v previous %v", throttle.Changed(), throttle.Previous())
+	}
+
+	if got := throttle.Get(); got != 0.8 {
+		t.Fatalf("expected released handle to read 0.8, got %v", got)
+	}
+}
@@ -0,0 +1,67 @@
+//go:build !wasm
+
+package vehicle
+
+import (
+	"github.com/oriolus-software/script-go/internal/host"
+)
+
+func isCoupled(bogie uint32) uint32 {
+	if host.Current.IsCoupled(bogie) {
+		return 1
+	}
+
+	return 0
+}
+
+func bogieIsValid(bogie uint32) uint32 {
+	return host.Current.BogieIsValid(bogie)
+}
+
+func axleIsValid(bogie, axle uint32) uint32 {
+	return host.Current.AxleIsValid(bogie, axle)
+}
+
+func pantographIsValid(end uint32) uint32 {
+	return host.Current.PantographIsValid(end)
+}
+
+func railQuality(bogie, axle uint32) uint32 {
+	return host.Current.Axle(bogie, axle).RailQuality
+}
+
+func surfaceType(bogie, axle uint32) uint32 {
+	return host.Current.Axle(bogie, axle).SurfaceType
+}
+
+func inverseRadius(bogie, axle uint32) float32 {
+	return host.Current.Axle(bogie, axle).InverseRadius
+}
+
+func VelocityVsGround() float32 {
+	return host.Current.Vehicle.Velocity
+}
+
+func AccelerationVsGround() float32 {
+	return host.Current.Vehicle.Acceleration
+}
+
+func pantographHeight(pantograph uint32) float64 {
+	return host.Current.Pantograph(pantograph).Height
+}
+
+func pantographVoltage(pantograph uint32) float64 {
+	return host.Current.Pantograph(pantograph).Voltage
+}
+
+func setTractionForceNewton(bogie, axle uint32, value float32) {
+	host.Current.Axle(bogie, axle).TractionForce = value
+}
+
+func setBrakeForceNewton(bogie, axle uint32, value float32) {
+	host.Current.Axle(bogie, axle).BrakeForce = value
+}
+
+func setRailBrakeForceNewton(bogie uint32, value float32) {
+	host.Current.Bogie(bogie).RailBrakeForce = value
+}
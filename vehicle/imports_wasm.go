@@ -0,0 +1,59 @@
+//go:build wasm
+
+package vehicle
+
+//go:wasm-module vehicle
+//export is_coupled
+func isCoupled(bogie uint32) uint32
+
+//go:wasm-module vehicle
+//export bogie_is_valid
+func bogieIsValid(bogie uint32) uint32
+
+//go:wasm-module vehicle
+//export axle_is_valid
+func axleIsValid(bogie, axle uint32) uint32
+
+//go:wasm-module vehicle
+//export pantograph_is_valid
+func pantographIsValid(end uint32) uint32
+
+//go:wasm-module vehicle
+//export rail_quality
+func railQuality(bogie, axle uint32) uint32
+
+//go:wasm-module vehicle
+//export surface_type
+func surfaceType(bogie, axle uint32) uint32
+
+//go:wasm-module vehicle
+//export inverse_radius
+func inverseRadius(bogie, axle uint32) float32
+
+//go:wasm-module vehicle
+//export velocity_vs_ground
+func VelocityVsGround() float32
+
+//go:wasm-module vehicle
+//export acceleration_vs_ground
+func AccelerationVsGround() float32
+
+//go:wasm-module vehicle
+//export pantograph_height
+func pantographHeight(pantograph uint32) float64
+
+//go:wasm-module vehicle
+//export pantograph_voltage
+func pantographVoltage(pantograph uint32) float64
+
+//go:wasm-module vehicle
+//export set_traction_force_newton
+func setTractionForceNewton(bogie, axle uint32, value float32)
+
+//go:wasm-module vehicle
+//export set_brake_force_newton
+func setBrakeForceNewton(bogie, axle uint32, value float32)
+
+//go:wasm-module vehicle
+//export set_rail_brake_force_newton
+func setRailBrakeForceNewton(bogie uint32, value float32)